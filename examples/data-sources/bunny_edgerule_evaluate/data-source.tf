@@ -0,0 +1,38 @@
+data "bunny_edgerule_evaluate" "blog" {
+  edgerule {
+    description           = "redirect to https"
+    action_type           = "force_ssl"
+    trigger_matching_type = "all"
+    trigger {
+      pattern_matching_type = "any"
+      type                  = "url"
+      pattern_matches       = ["http://*"]
+    }
+  }
+
+  edgerule {
+    description = "moved blog"
+    redirect {
+      url         = "https://blog.example.com{{path}}"
+      status_code = 301
+    }
+    trigger_matching_type = "all"
+    trigger {
+      pattern_matching_type = "any"
+      type                  = "url"
+      pattern_matches       = ["*/blog/*"]
+    }
+  }
+
+  request {
+    url          = "https://cdn.example.com/blog/hello-world"
+    country_code = "DE"
+  }
+}
+
+check "blog_is_redirected" {
+  assert {
+    condition     = [for a in data.bunny_edgerule_evaluate.blog.actions : a.action_type] == ["redirect"]
+    error_message = "requests to /blog/ must only be redirected"
+  }
+}
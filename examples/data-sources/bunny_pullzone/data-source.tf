@@ -0,0 +1,11 @@
+data "bunny_pullzone" "by_id" {
+  id = "123"
+}
+
+data "bunny_pullzone" "by_name" {
+  name = "mypullzone"
+}
+
+output "cname_domain" {
+  value = data.bunny_pullzone.by_name.cname_domain
+}
@@ -0,0 +1,8 @@
+data "bunny_pullzones" "web" {
+  name_prefix = "web-"
+  enabled     = true
+}
+
+output "pull_zone_ids" {
+  value = [for pz in data.bunny_pullzones.web.pull_zones : pz.id]
+}
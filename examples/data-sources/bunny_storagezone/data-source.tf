@@ -0,0 +1,7 @@
+data "bunny_storagezone" "assets" {
+  name = "assets"
+}
+
+output "storage_hostname" {
+  value = data.bunny_storagezone.assets.hostname
+}
@@ -0,0 +1,31 @@
+resource "bunny_edgerule" "redirect_blog" {
+  pull_zone_id = bunny_pullzone.mypz.id
+
+  redirect {
+    url         = "https://blog.example.com{{path}}"
+    status_code = 308
+  }
+
+  trigger_matching_type = "all"
+  trigger {
+    pattern_matching_type = "any"
+    type                  = "url"
+    pattern_matches       = ["*/blog/*"]
+  }
+}
+
+resource "bunny_edgerule" "frame_options" {
+  pull_zone_id = bunny_pullzone.mypz.id
+
+  set_response_header {
+    name  = "X-Frame-Options"
+    value = "DENY"
+  }
+
+  trigger_matching_type = "all"
+  trigger {
+    pattern_matching_type = "any"
+    type                  = "url"
+    pattern_matches       = ["*"]
+  }
+}
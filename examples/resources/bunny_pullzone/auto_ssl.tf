@@ -0,0 +1,10 @@
+resource "bunny_pullzone" "pullzone-terraform" {
+  name       = "pz-terraform"
+  origin_url = "https://terraform.io"
+  auto_ssl   = true
+}
+
+resource "bunny_hostname" "cdn" {
+  pull_zone_id = bunny_pullzone.pullzone-terraform.id
+  hostname     = "cdn.example.com"
+}
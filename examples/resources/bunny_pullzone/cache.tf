@@ -0,0 +1,14 @@
+resource "bunny_pullzone" "pullzone-terraform" {
+  name       = "pz-terraform"
+  origin_url = "https://terraform.io"
+
+  cache_control_max_age_override = 3600
+  cache_error_responses          = true
+
+  cache {
+    enable_smart_cache       = true
+    use_background_update    = true
+    use_stale_while_offline  = true
+    use_stale_while_updating = true
+  }
+}
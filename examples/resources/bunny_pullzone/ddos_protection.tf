@@ -0,0 +1,9 @@
+resource "bunny_pullzone" "pullzone-terraform" {
+  name       = "pz-terraform"
+  origin_url = "https://terraform.io"
+
+  ddos_protection {
+    enabled = true
+    type    = "active_standard"
+  }
+}
@@ -0,0 +1,13 @@
+resource "bunny_pullzone" "pullzone-terraform" {
+  name       = "pz-terraform"
+  origin_url = "https://terraform.io"
+  type       = 1
+
+  enable_geo_zone_af   = false
+  enable_geo_zone_asia = false
+  enable_geo_zone_eu   = true
+  enable_geo_zone_sa   = false
+  enable_geo_zone_us   = true
+
+  budget_redirected_countries = ["IN", "ZA"]
+}
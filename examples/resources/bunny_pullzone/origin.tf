@@ -0,0 +1,9 @@
+resource "bunny_pullzone" "pullzone-terraform" {
+  name       = "pz-terraform"
+  origin_url = "https://terraform.io"
+
+  origin {
+    type        = "dns_accelerate"
+    host_header = "www.terraform.io"
+  }
+}
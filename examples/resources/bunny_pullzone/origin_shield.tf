@@ -0,0 +1,13 @@
+resource "bunny_pullzone" "pullzone-terraform" {
+  name       = "pz-terraform"
+  origin_url = "https://terraform.io"
+
+  origin_shield {
+    enabled                  = true
+    zone_code                = "FR"
+    enable_concurrency_limit = true
+    max_concurrent_requests  = 100
+    max_queued_requests      = 1000
+    queue_max_wait_time      = 10
+  }
+}
@@ -0,0 +1,12 @@
+resource "bunny_pullzone" "pullzone-terraform" {
+  name                 = "pz-terraform"
+  origin_url           = "https://terraform.io"
+  ignore_query_strings = false
+
+  vary_cache {
+    enable_cookie_vary           = true
+    enable_webp_vary             = true
+    cookie_vary_parameters       = ["ab_test_group"]
+    query_string_vary_parameters = ["lang", "version"]
+  }
+}
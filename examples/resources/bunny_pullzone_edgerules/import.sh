@@ -0,0 +1 @@
+terraform import bunny_pullzone_edgerules.example <PULLZONE-ID>
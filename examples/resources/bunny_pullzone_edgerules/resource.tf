@@ -0,0 +1,30 @@
+resource "bunny_pullzone" "mypz" {
+  name       = "testpz123aye"
+  origin_url = "https://bunny.net"
+}
+
+resource "bunny_pullzone_edgerules" "myers" {
+  pull_zone_id = bunny_pullzone.mypz.id
+
+  edgerule {
+    description           = "redirect to https"
+    action_type           = "force_ssl"
+    trigger_matching_type = "all"
+    trigger {
+      pattern_matching_type = "any"
+      type                  = "url"
+      pattern_matches       = ["http://*"]
+    }
+  }
+
+  edgerule {
+    description           = "block half of the requests"
+    action_type           = "block_request"
+    trigger_matching_type = "all"
+    trigger {
+      pattern_matching_type = "any"
+      type                  = "random_chance"
+      pattern_matches       = ["50"]
+    }
+  }
+}
@@ -0,0 +1,536 @@
+package bunnytest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const cnameDomainSuffix = ".b-cdn.net"
+
+func (s *Server) routePullZone(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, paginate(r, s.pullZones))
+		case http.MethodPost:
+			s.addPullZone(w, r)
+		default:
+			writeMethodNotAllowed(w)
+		}
+
+		return
+	}
+
+	if path[0] == "loadFreeCertificate" {
+		s.loadFreeCertificate(w, r)
+		return
+	}
+
+	id, ok := parseID(w, path[0])
+	if !ok {
+		return
+	}
+
+	pz, exists := s.pullZones[id]
+	if !exists {
+		writeAPIError(w, http.StatusNotFound, "pullzone.not_found", "Id", "The requested Pull Zone was not found")
+		return
+	}
+
+	if len(path) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, pz)
+		case http.MethodPost:
+			s.updatePullZone(w, r, pz)
+		case http.MethodDelete:
+			delete(s.pullZones, id)
+			writeNoContent(w)
+		default:
+			writeMethodNotAllowed(w)
+		}
+
+		return
+	}
+
+	switch path[1] {
+	case "addHostname":
+		s.addHostname(w, r, pz)
+	case "removeHostname":
+		s.removeHostname(w, r, pz)
+	case "setForceSSL":
+		s.setForceSSL(w, r, pz)
+	case "addCertificate":
+		s.addCertificate(w, r, pz)
+	case "removeCertificate":
+		s.removeCertificate(w, r, pz)
+	case "addBlockedReferrer":
+		s.addBlockedReferrer(w, r, pz)
+	case "removeBlockedReferrer":
+		s.removeBlockedReferrer(w, r, pz)
+	case "edgerules":
+		s.routeEdgeRule(w, r, pz, path[2:])
+	default:
+		writeAPIError(w, http.StatusNotFound, "not_found", "", "endpoint does not exist")
+	}
+}
+
+func (s *Server) addPullZone(w http.ResponseWriter, r *http.Request) {
+	var opts bunny.PullZoneAddOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	if opts.Name == "" {
+		writeAPIError(w, http.StatusBadRequest, "validation", "Name", "The Name field is required")
+		return
+	}
+
+	originType := opts.OriginType
+	switch {
+	case originType != nil && *originType == bunny.OriginTypeEdgeScript:
+		if ptr.GetInt64(opts.EdgeScriptID) == 0 {
+			writeAPIError(w, http.StatusBadRequest, "validation", "EdgeScriptId", "The EdgeScriptId field is required")
+			return
+		}
+
+	case (opts.OriginURL == "") == (ptr.GetInt64(opts.StorageZoneID) == 0):
+		writeAPIError(w, http.StatusBadRequest, "validation", "OriginUrl", "Either OriginUrl or StorageZoneId must be set")
+		return
+
+	case originType == nil && opts.OriginURL != "":
+		originType = ptr.ToInt32(bunny.OriginTypeURL)
+
+	case originType == nil:
+		originType = ptr.ToInt32(bunny.OriginTypeStorageZone)
+	}
+
+	for _, pz := range s.pullZones {
+		if *pz.Name == opts.Name {
+			writeAPIError(w, http.StatusBadRequest, "pullzone.name_taken", "Name", "The pull zone name is already taken")
+			return
+		}
+	}
+
+	id := s.nextID()
+	cname := opts.Name + cnameDomainSuffix
+
+	pz := bunny.PullZone{
+		ID:                ptr.ToInt64(id),
+		Name:              ptr.ToString(opts.Name),
+		OriginURL:         ptr.ToString(opts.OriginURL),
+		StorageZoneID:     opts.StorageZoneID,
+		OriginType:        originType,
+		EdgeScriptID:      opts.EdgeScriptID,
+		Type:              ptr.ToInt(opts.Type),
+		Enabled:           ptr.ToBool(true),
+		CnameDomain:       ptr.ToString(cname),
+		ZoneSecurityKey:   ptr.ToString(uuid.New().String()),
+		EnableGeoZoneAF:   ptr.ToBool(true),
+		EnableGeoZoneAsia: ptr.ToBool(true),
+		EnableGeoZoneEU:   ptr.ToBool(true),
+		EnableGeoZoneSA:   ptr.ToBool(true),
+		EnableGeoZoneUS:   ptr.ToBool(true),
+
+		// default settings of a new Pull Zone at bunny.net
+		AccessControlOriginHeaderExtensions:   []string{"eot", "ttf", "woff", "woff2", "css"},
+		CacheControlBrowserMaxAgeOverride:     ptr.ToInt64(-1),
+		CacheControlMaxAgeOverride:            ptr.ToInt64(-1),
+		DisableCookies:                        ptr.ToBool(true),
+		EnableAccessControlOriginHeader:       ptr.ToBool(true),
+		EnableLogging:                         ptr.ToBool(true),
+		EnableTLS1:                            ptr.ToBool(true),
+		EnableTLS11:                           ptr.ToBool(true),
+		IgnoreQueryStrings:                    ptr.ToBool(true),
+		LoggingIPAnonymizationEnabled:         ptr.ToBool(true),
+		OptimizerAutomaticOptimizationEnabled: ptr.ToBool(true),
+		OptimizerDesktopMaxWidth:              ptr.ToInt32(1600),
+		OptimizerEnableManipulationEngine:     ptr.ToBool(true),
+		OptimizerEnableWebP:                   ptr.ToBool(true),
+		OptimizerImageQuality:                 ptr.ToInt32(85),
+		OptimizerMinifyCSS:                    ptr.ToBool(true),
+		OptimizerMinifyJavaScript:             ptr.ToBool(true),
+		OptimizerMobileImageQuality:           ptr.ToInt32(70),
+		OptimizerMobileMaxWidth:               ptr.ToInt32(800),
+		OptimizerWatermarkMinImageSize:        ptr.ToInt32(300),
+		OptimizerWatermarkOffset:              ptr.ToFloat64(3),
+		OriginRetryConnectionTimeout:          ptr.ToBool(true),
+		OriginRetryResponseTimeout:            ptr.ToBool(true),
+		OriginShieldMaxConcurrentRequests:     ptr.ToInt32(200),
+		OriginShieldMaxQueuedRequests:         ptr.ToInt32(5000),
+		OriginShieldQueueMaxWaitTime:          ptr.ToInt32(30),
+		OriginShieldZoneCode:                  ptr.ToString("FR"),
+		ShieldDDosProtectionEnabled:           ptr.ToBool(true),
+		ShieldDDosProtectionType:              ptr.ToInt(bunny.DDoSProtectionTypeActiveStandard),
+
+		Hostnames: []*bunny.Hostname{
+			{
+				ID:               ptr.ToInt64(s.nextID()),
+				Value:            ptr.ToString(cname),
+				ForceSSL:         ptr.ToBool(false),
+				IsSystemHostname: ptr.ToBool(true),
+				HasCertificate:   ptr.ToBool(true),
+			},
+		},
+	}
+
+	s.pullZones[id] = &pz
+
+	writeJSON(w, http.StatusCreated, &pz)
+}
+
+func (s *Server) updatePullZone(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_body", "", "reading request body failed: "+err.Error())
+		return
+	}
+
+	var opts bunny.PullZoneUpdateOptions
+	if err := json.Unmarshal(body, &opts); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_body", "", "parsing request body failed: "+err.Error())
+		return
+	}
+
+	// Most fields of PullZoneUpdateOptions have the same JSON name then
+	// the fields in PullZone, unmarshaling the body into the existing
+	// pull zone only overwrites the fields that are set.
+	updated := deepCopy(pz)
+	if err := json.Unmarshal(body, updated); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_body", "", "parsing request body failed: "+err.Error())
+		return
+	}
+
+	if opts.CacheControlBrowserMaxAgeOverride != nil {
+		updated.CacheControlBrowserMaxAgeOverride = opts.CacheControlBrowserMaxAgeOverride
+	}
+
+	*pz = *updated
+
+	writeJSON(w, http.StatusOK, pz)
+}
+
+func findHostname(pz *bunny.PullZone, hostname string) (int, *bunny.Hostname) {
+	for i, h := range pz.Hostnames {
+		if strings.EqualFold(*h.Value, hostname) {
+			return i, h
+		}
+	}
+
+	return -1, nil
+}
+
+func hostnameFromBody(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) (int, *bunny.Hostname) {
+	var opts struct {
+		Hostname *string
+	}
+
+	if !decodeBody(w, r, &opts) {
+		return -1, nil
+	}
+
+	if opts.Hostname == nil || *opts.Hostname == "" {
+		writeAPIError(w, http.StatusBadRequest, "validation", "Hostname", "The Hostname field is required")
+		return -1, nil
+	}
+
+	i, h := findHostname(pz, *opts.Hostname)
+	if h == nil {
+		writeAPIError(w, http.StatusNotFound, "pullzone.hostname_not_found", "Hostname", "The requested hostname was not found")
+		return -1, nil
+	}
+
+	return i, h
+}
+
+func (s *Server) addHostname(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	var opts bunny.AddCustomHostnameOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	if opts.Hostname == nil || *opts.Hostname == "" {
+		writeAPIError(w, http.StatusBadRequest, "validation", "Hostname", "The Hostname field is required")
+		return
+	}
+
+	for _, otherPz := range s.pullZones {
+		if _, h := findHostname(otherPz, *opts.Hostname); h != nil {
+			writeAPIError(w, http.StatusBadRequest, "pullzone.hostname_already_registered", "Hostname", "The hostname is already registered")
+			return
+		}
+	}
+
+	pz.Hostnames = append(pz.Hostnames, &bunny.Hostname{
+		ID:               ptr.ToInt64(s.nextID()),
+		Value:            opts.Hostname,
+		ForceSSL:         ptr.ToBool(false),
+		IsSystemHostname: ptr.ToBool(false),
+		HasCertificate:   ptr.ToBool(false),
+	})
+
+	writeNoContent(w)
+}
+
+func (s *Server) removeHostname(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	i, h := hostnameFromBody(w, r, pz)
+	if h == nil {
+		return
+	}
+
+	if *h.IsSystemHostname {
+		writeAPIError(w, http.StatusBadRequest, "pullzone.hostname_system", "Hostname", "System hostnames can not be removed")
+		return
+	}
+
+	pz.Hostnames = append(pz.Hostnames[:i], pz.Hostnames[i+1:]...)
+
+	writeNoContent(w)
+}
+
+func (s *Server) setForceSSL(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	var opts bunny.SetForceSSLOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	if opts.Hostname == nil {
+		writeAPIError(w, http.StatusBadRequest, "validation", "Hostname", "The Hostname field is required")
+		return
+	}
+
+	_, h := findHostname(pz, *opts.Hostname)
+	if h == nil {
+		writeAPIError(w, http.StatusNotFound, "pullzone.hostname_not_found", "Hostname", "The requested hostname was not found")
+		return
+	}
+
+	h.ForceSSL = ptr.ToBool(ptr.GetBool(opts.ForceSSL))
+
+	writeNoContent(w)
+}
+
+func (s *Server) addCertificate(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	var opts bunny.PullZoneAddCustomCertificateOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	if len(opts.Certificate) == 0 || len(opts.CertificateKey) == 0 {
+		writeAPIError(w, http.StatusBadRequest, "validation", "Certificate", "The Certificate and CertificateKey fields are required")
+		return
+	}
+
+	_, h := findHostname(pz, opts.Hostname)
+	if h == nil {
+		writeAPIError(w, http.StatusNotFound, "pullzone.hostname_not_found", "Hostname", "The requested hostname was not found")
+		return
+	}
+
+	h.HasCertificate = ptr.ToBool(true)
+
+	writeNoContent(w)
+}
+
+func (s *Server) removeCertificate(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	_, h := hostnameFromBody(w, r, pz)
+	if h == nil {
+		return
+	}
+
+	h.HasCertificate = ptr.ToBool(false)
+
+	writeNoContent(w)
+}
+
+func (s *Server) addBlockedReferrer(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	var opts bunny.AddBlockedReferrerOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	if opts.Hostname == nil || *opts.Hostname == "" {
+		writeAPIError(w, http.StatusBadRequest, "validation", "BlockedHostname", "The BlockedHostname field is required")
+		return
+	}
+
+	for _, ref := range pz.BlockedReferrers {
+		if strings.EqualFold(ref, *opts.Hostname) {
+			writeNoContent(w)
+			return
+		}
+	}
+
+	pz.BlockedReferrers = append(pz.BlockedReferrers, *opts.Hostname)
+
+	writeNoContent(w)
+}
+
+func (s *Server) removeBlockedReferrer(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	var opts bunny.RemoveBlockedReferrerOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	if opts.Hostname == nil || *opts.Hostname == "" {
+		writeAPIError(w, http.StatusBadRequest, "validation", "BlockedHostname", "The BlockedHostname field is required")
+		return
+	}
+
+	for i, ref := range pz.BlockedReferrers {
+		if strings.EqualFold(ref, *opts.Hostname) {
+			pz.BlockedReferrers = append(pz.BlockedReferrers[:i], pz.BlockedReferrers[i+1:]...)
+			break
+		}
+	}
+
+	writeNoContent(w)
+}
+
+func (s *Server) loadFreeCertificate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	hostname := r.URL.Query().Get("hostname")
+
+	for _, pz := range s.pullZones {
+		if _, h := findHostname(pz, hostname); h != nil {
+			h.HasCertificate = ptr.ToBool(true)
+			writeNoContent(w)
+			return
+		}
+	}
+
+	writeAPIError(w, http.StatusBadRequest, "pullzone.hostname_not_found", "Hostname", "The requested hostname was not found")
+}
+
+func (s *Server) routeEdgeRule(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone, path []string) {
+	if len(path) == 1 && path[0] == "addOrUpdate" {
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w)
+			return
+		}
+
+		s.addOrUpdateEdgeRule(w, r, pz)
+		return
+	}
+
+	if len(path) == 0 || len(path) > 2 {
+		writeAPIError(w, http.StatusNotFound, "not_found", "", "endpoint does not exist")
+		return
+	}
+
+	i := findEdgeRule(pz, path[0])
+	if i == -1 {
+		writeAPIError(w, http.StatusNotFound, "pullzone.edgerule_not_found", "EdgeRuleId", "The requested edge rule was not found")
+		return
+	}
+
+	if len(path) == 1 {
+		if r.Method != http.MethodDelete {
+			writeMethodNotAllowed(w)
+			return
+		}
+
+		pz.EdgeRules = append(pz.EdgeRules[:i], pz.EdgeRules[i+1:]...)
+		writeNoContent(w)
+		return
+	}
+
+	if path[1] != "setEdgeRuleEnabled" || r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusNotFound, "not_found", "", "endpoint does not exist")
+		return
+	}
+
+	var opts bunny.SetEdgeRuleEnabledOptions
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	pz.EdgeRules[i].Enabled = ptr.ToBool(ptr.GetBool(opts.Value))
+
+	writeNoContent(w)
+}
+
+func findEdgeRule(pz *bunny.PullZone, guid string) int {
+	for i, er := range pz.EdgeRules {
+		if er.GUID != nil && *er.GUID == guid {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) addOrUpdateEdgeRule(w http.ResponseWriter, r *http.Request, pz *bunny.PullZone) {
+	var opts bunny.AddOrUpdateEdgeRuleOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	if opts.ActionType == nil {
+		writeAPIError(w, http.StatusBadRequest, "validation", "ActionType", "The ActionType field is required")
+		return
+	}
+
+	if len(opts.Triggers) > 5 {
+		writeAPIError(w, http.StatusBadRequest, "validation", "Triggers", "Maximum 5 condition are allowed per rule.")
+		return
+	}
+
+	er := bunny.EdgeRule{
+		GUID:                opts.GUID,
+		ActionType:          opts.ActionType,
+		ActionParameter1:    ptr.ToString(ptr.GetString(opts.ActionParameter1)),
+		ActionParameter2:    ptr.ToString(ptr.GetString(opts.ActionParameter2)),
+		Triggers:            opts.Triggers,
+		TriggerMatchingType: ptr.ToInt(ptr.GetInt(opts.TriggerMatchingType)),
+		Description:         ptr.ToString(ptr.GetString(opts.Description)),
+		Enabled:             ptr.ToBool(ptr.GetBool(opts.Enabled)),
+		OrderIndex:          opts.OrderIndex,
+	}
+
+	if ptr.GetString(opts.GUID) == "" {
+		er.GUID = ptr.ToString(uuid.New().String())
+		pz.EdgeRules = append(pz.EdgeRules, &er)
+		sortEdgeRules(pz)
+		writeNoContent(w)
+		return
+	}
+
+	i := findEdgeRule(pz, *opts.GUID)
+	if i == -1 {
+		writeAPIError(w, http.StatusNotFound, "pullzone.edgerule_not_found", "Guid", "The requested edge rule was not found")
+		return
+	}
+
+	pz.EdgeRules[i] = &er
+	sortEdgeRules(pz)
+
+	writeNoContent(w)
+}
+
+// sortEdgeRules orders the edge rules of pz by their OrderIndex, like the
+// bunny.net API returns them. Edge rules without an OrderIndex keep their
+// position relative to each other.
+func sortEdgeRules(pz *bunny.PullZone) {
+	sort.SliceStable(pz.EdgeRules, func(i, j int) bool {
+		return ptr.GetInt32(pz.EdgeRules[i].OrderIndex) < ptr.GetInt32(pz.EdgeRules[j].OrderIndex)
+	})
+}
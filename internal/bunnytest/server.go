@@ -0,0 +1,217 @@
+// Package bunnytest provides an in-memory fake of the bunny.net HTTP API for
+// tests.
+package bunnytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+// APIKey is the API key that the Server accepts.
+const APIKey = "bunnytest-api-key"
+
+// Server is an in-memory fake of the bunny.net HTTP API.
+// It implements the Pull Zone, Edge Rule, Hostname, Certificate and Storage
+// Zone endpoints that are used by the terraform provider.
+// Requests that are not authenticated with APIKey are rejected.
+type Server struct {
+	srv *httptest.Server
+
+	// URL is the base URL of the server, it can be passed to
+	// bunny.WithBaseURL().
+	URL string
+
+	mu           sync.Mutex
+	lastID       int64
+	pullZones    map[int64]*bunny.PullZone
+	storageZones map[int64]*bunny.StorageZone
+}
+
+// NewServer starts and returns a new Server.
+// The caller must call Close when it is not needed anymore.
+func NewServer() *Server {
+	s := Server{
+		pullZones:    map[int64]*bunny.PullZone{},
+		storageZones: map[int64]*bunny.StorageZone{},
+	}
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+
+	return &s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// PullZone returns a copy of the Pull Zone with the given ID.
+func (s *Server) PullZone(id int64) (*bunny.PullZone, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pz, exists := s.pullZones[id]
+	if !exists {
+		return nil, false
+	}
+
+	return deepCopy(pz), true
+}
+
+// StorageZone returns a copy of the Storage Zone with the given ID.
+func (s *Server) StorageZone(id int64) (*bunny.StorageZone, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sz, exists := s.storageZones[id]
+	if !exists {
+		return nil, false
+	}
+
+	return s.storageZoneWithPullZones(sz), true
+}
+
+// nextID returns a new unique ID. s.mu must be held by the caller.
+func (s *Server) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(bunny.AccessKeyHeaderKey) != APIKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Authorization has been denied for this request."))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch path[0] {
+	case "pullzone":
+		s.routePullZone(w, r, path[1:])
+	case "storagezone":
+		s.routeStorageZone(w, r, path[1:])
+	default:
+		writeAPIError(w, http.StatusNotFound, "not_found", "", "endpoint does not exist")
+	}
+}
+
+func parseID(w http.ResponseWriter, s string) (int64, bool) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_id", "id", "id must be an integer")
+		return -1, false
+	}
+
+	return id, true
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_body", "", "parsing request body failed: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, errKey, field, msg string) {
+	writeJSON(w, status, &bunny.APIError{
+		ErrorKey: errKey,
+		Field:    field,
+		Message:  msg,
+	})
+}
+
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeAPIError(w, http.StatusMethodNotAllowed, "method_not_allowed", "", "method is not allowed")
+}
+
+// paginate returns the page of items that was requested via the page and
+// per_page query parameters of r.
+func paginate[Item any](r *http.Request, items map[int64]*Item) *bunny.PaginationReply[Item] {
+	ids := sortedIDs(items)
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = bunny.DefaultPaginationPage
+	}
+
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = bunny.DefaultPaginationPerPage
+	}
+
+	start := (page - 1) * perPage
+	if start > len(ids) {
+		start = len(ids)
+	}
+
+	end := start + perPage
+	if end > len(ids) {
+		end = len(ids)
+	}
+
+	res := bunny.PaginationReply[Item]{
+		Items:        make([]*Item, 0, end-start),
+		CurrentPage:  ptr.ToInt32(int32(page)),
+		TotalItems:   ptr.ToInt32(int32(len(ids))),
+		HasMoreItems: ptr.ToBool(end < len(ids)),
+	}
+
+	for _, id := range ids[start:end] {
+		res.Items = append(res.Items, items[id])
+	}
+
+	return &res
+}
+
+// sortedIDs returns the keys of items in ascending order.
+func sortedIDs[Item any](items map[int64]*Item) []int64 {
+	res := make([]int64, 0, len(items))
+	for id := range items {
+		res = append(res, id)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+
+	return res
+}
+
+// deepCopy returns a deep copy of v by marshaling and unmarshaling it.
+func deepCopy[T any](v *T) *T {
+	var res T
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := json.Unmarshal(buf, &res); err != nil {
+		panic(err)
+	}
+
+	return &res
+}
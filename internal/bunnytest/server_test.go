@@ -0,0 +1,209 @@
+package bunnytest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+func newTestClient(t *testing.T) (*Server, *bunny.Client) {
+	t.Helper()
+
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	return srv, bunny.NewClient(APIKey, bunny.WithBaseURL(srv.URL))
+}
+
+func TestUnauthenticatedRequestIsRejected(t *testing.T) {
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	clt := bunny.NewClient("invalid", bunny.WithBaseURL(srv.URL))
+
+	_, err := clt.PullZone.Get(context.Background(), 1)
+
+	var authErr *bunny.AuthenticationError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected AuthenticationError, got: %v", err)
+	}
+}
+
+func TestPullZoneLifecycle(t *testing.T) {
+	ctx := context.Background()
+	srv, clt := newTestClient(t)
+
+	pz, err := clt.PullZone.Add(ctx, &bunny.PullZoneAddOptions{
+		Name:      "test",
+		OriginURL: "https://bunny.net",
+	})
+	if err != nil {
+		t.Fatalf("adding pull zone failed: %s", err)
+	}
+
+	if ptr.GetString(pz.CnameDomain) != "test.b-cdn.net" {
+		t.Errorf("unexpected cname domain: %q", ptr.GetString(pz.CnameDomain))
+	}
+
+	updated, err := clt.PullZone.Update(ctx, *pz.ID, &bunny.PullZoneUpdateOptions{
+		CacheControlBrowserMaxAgeOverride: ptr.ToInt64(300),
+		FollowRedirects:                   ptr.ToBool(true),
+	})
+	if err != nil {
+		t.Fatalf("updating pull zone failed: %s", err)
+	}
+
+	if ptr.GetInt64(updated.CacheControlBrowserMaxAgeOverride) != 300 {
+		t.Errorf("CacheControlBrowserMaxAgeOverride was not updated")
+	}
+
+	if ptr.GetString(updated.OriginURL) != "https://bunny.net" {
+		t.Errorf("update changed unset field OriginURL to %q", ptr.GetString(updated.OriginURL))
+	}
+
+	stored, exists := srv.PullZone(*pz.ID)
+	if !exists {
+		t.Fatal("pull zone does not exist in server")
+	}
+
+	if !ptr.GetBool(stored.FollowRedirects) {
+		t.Error("FollowRedirects was not updated")
+	}
+
+	if err := clt.PullZone.Delete(ctx, *pz.ID); err != nil {
+		t.Fatalf("deleting pull zone failed: %s", err)
+	}
+
+	_, err = clt.PullZone.Get(ctx, *pz.ID)
+
+	var apiErr *bunny.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected APIError with status code 404, got: %v", err)
+	}
+}
+
+func TestPullZoneListPagination(t *testing.T) {
+	ctx := context.Background()
+	_, clt := newTestClient(t)
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := clt.PullZone.Add(ctx, &bunny.PullZoneAddOptions{Name: name, OriginURL: "https://bunny.net"})
+		if err != nil {
+			t.Fatalf("adding pull zone failed: %s", err)
+		}
+	}
+
+	page1, err := clt.PullZone.List(ctx, &bunny.PaginationOptions{Page: 1, PerPage: 2})
+	if err != nil {
+		t.Fatalf("listing pull zones failed: %s", err)
+	}
+
+	if len(page1.Items) != 2 || !*page1.HasMoreItems || *page1.TotalItems != 3 {
+		t.Errorf("unexpected first page: %d items, HasMoreItems: %t, TotalItems: %d",
+			len(page1.Items), *page1.HasMoreItems, *page1.TotalItems)
+	}
+
+	page2, err := clt.PullZone.List(ctx, &bunny.PaginationOptions{Page: 2, PerPage: 2})
+	if err != nil {
+		t.Fatalf("listing pull zones failed: %s", err)
+	}
+
+	if len(page2.Items) != 1 || *page2.HasMoreItems {
+		t.Errorf("unexpected second page: %d items, HasMoreItems: %t", len(page2.Items), *page2.HasMoreItems)
+	}
+}
+
+func TestHostnamesAndEdgeRules(t *testing.T) {
+	ctx := context.Background()
+	srv, clt := newTestClient(t)
+
+	pz, err := clt.PullZone.Add(ctx, &bunny.PullZoneAddOptions{Name: "test", OriginURL: "https://bunny.net"})
+	if err != nil {
+		t.Fatalf("adding pull zone failed: %s", err)
+	}
+
+	hostname := "cdn.example.com"
+
+	if err := clt.PullZone.AddCustomHostname(ctx, *pz.ID, &bunny.AddCustomHostnameOptions{Hostname: &hostname}); err != nil {
+		t.Fatalf("adding hostname failed: %s", err)
+	}
+
+	if err := clt.PullZone.SetForceSSL(ctx, *pz.ID, &bunny.SetForceSSLOptions{Hostname: &hostname, ForceSSL: ptr.ToBool(true)}); err != nil {
+		t.Fatalf("setting force ssl failed: %s", err)
+	}
+
+	if err := clt.PullZone.LoadFreeCertificate(ctx, hostname); err != nil {
+		t.Fatalf("loading free certificate failed: %s", err)
+	}
+
+	err = clt.PullZone.AddOrUpdateEdgeRule(ctx, *pz.ID, &bunny.AddOrUpdateEdgeRuleOptions{
+		ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeBlockRequest),
+		TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+		Description:         ptr.ToString("test"),
+		Enabled:             ptr.ToBool(true),
+	})
+	if err != nil {
+		t.Fatalf("adding edge rule failed: %s", err)
+	}
+
+	stored, _ := srv.PullZone(*pz.ID)
+
+	if len(stored.Hostnames) != 2 {
+		t.Fatalf("expected pull zone to have 2 hostnames, has: %d", len(stored.Hostnames))
+	}
+
+	h := stored.Hostnames[1]
+	if !ptr.GetBool(h.ForceSSL) || !ptr.GetBool(h.HasCertificate) {
+		t.Errorf("unexpected hostname state, ForceSSL: %t, HasCertificate: %t", ptr.GetBool(h.ForceSSL), ptr.GetBool(h.HasCertificate))
+	}
+
+	if len(stored.EdgeRules) != 1 || ptr.GetString(stored.EdgeRules[0].GUID) == "" {
+		t.Fatalf("expected pull zone to have 1 edge rule with a guid, has: %+v", stored.EdgeRules)
+	}
+
+	if err := clt.PullZone.DeleteEdgeRule(ctx, *pz.ID, *stored.EdgeRules[0].GUID); err != nil {
+		t.Fatalf("deleting edge rule failed: %s", err)
+	}
+
+	if err := clt.PullZone.RemoveCustomHostname(ctx, *pz.ID, &bunny.RemoveCustomHostnameOptions{Hostname: &hostname}); err != nil {
+		t.Fatalf("removing hostname failed: %s", err)
+	}
+
+	stored, _ = srv.PullZone(*pz.ID)
+	if len(stored.EdgeRules) != 0 || len(stored.Hostnames) != 1 {
+		t.Errorf("expected edge rule and hostname to be removed, pull zone has %d edge rules and %d hostnames",
+			len(stored.EdgeRules), len(stored.Hostnames))
+	}
+}
+
+func TestStorageZoneLinkedPullZones(t *testing.T) {
+	ctx := context.Background()
+	_, clt := newTestClient(t)
+
+	sz, err := clt.StorageZone.Add(ctx, &bunny.StorageZoneAddOptions{Name: ptr.ToString("test")})
+	if err != nil {
+		t.Fatalf("adding storage zone failed: %s", err)
+	}
+
+	if ptr.GetString(sz.Region) != storageZoneDefaultRegion {
+		t.Errorf("expected default region %q, got: %q", storageZoneDefaultRegion, ptr.GetString(sz.Region))
+	}
+
+	_, err = clt.PullZone.Add(ctx, &bunny.PullZoneAddOptions{Name: "test", StorageZoneID: sz.ID})
+	if err != nil {
+		t.Fatalf("adding pull zone failed: %s", err)
+	}
+
+	sz, err = clt.StorageZone.Get(ctx, *sz.ID)
+	if err != nil {
+		t.Fatalf("retrieving storage zone failed: %s", err)
+	}
+
+	if len(sz.PullZones) != 1 {
+		t.Errorf("expected storage zone to be linked to 1 pull zone, is linked to: %d", len(sz.PullZones))
+	}
+}
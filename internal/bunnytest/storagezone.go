@@ -0,0 +1,147 @@
+package bunnytest
+
+import (
+	"net/http"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const storageZoneDefaultRegion = "DE"
+
+func (s *Server) routeStorageZone(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			// the list endpoint does not include the linked Pull
+			// Zones, they are only returned when retrieving a single
+			// Storage Zone
+			writeJSON(w, http.StatusOK, paginate(r, s.storageZones))
+		case http.MethodPost:
+			s.addStorageZone(w, r)
+		default:
+			writeMethodNotAllowed(w)
+		}
+
+		return
+	}
+
+	if len(path) > 1 {
+		writeAPIError(w, http.StatusNotFound, "not_found", "", "endpoint does not exist")
+		return
+	}
+
+	id, ok := parseID(w, path[0])
+	if !ok {
+		return
+	}
+
+	sz, exists := s.storageZones[id]
+	if !exists {
+		writeAPIError(w, http.StatusNotFound, "storagezone.not_found", "Id", "The requested Storage Zone was not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.storageZoneWithPullZones(sz))
+	case http.MethodPost:
+		s.updateStorageZone(w, r, sz)
+	case http.MethodDelete:
+		delete(s.storageZones, id)
+		writeNoContent(w)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// storageZoneWithPullZones returns a copy of sz with the PullZones field set
+// to the Pull Zones that are linked to it.
+func (s *Server) storageZoneWithPullZones(sz *bunny.StorageZone) *bunny.StorageZone {
+	res := deepCopy(sz)
+
+	for _, id := range sortedIDs(s.pullZones) {
+		pz := s.pullZones[id]
+		if ptr.GetInt64(pz.StorageZoneID) == *sz.ID {
+			res.PullZones = append(res.PullZones, deepCopy(pz))
+		}
+	}
+
+	return res
+}
+
+func (s *Server) addStorageZone(w http.ResponseWriter, r *http.Request) {
+	var opts bunny.StorageZoneAddOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	if ptr.GetString(opts.Name) == "" {
+		writeAPIError(w, http.StatusBadRequest, "validation", "Name", "The Name field is required")
+		return
+	}
+
+	for _, sz := range s.storageZones {
+		if *sz.Name == *opts.Name {
+			writeAPIError(w, http.StatusBadRequest, "storagezone.name_taken", "Name", "The storage zone name is already taken")
+			return
+		}
+	}
+
+	region := ptr.GetString(opts.Region)
+	if region == "" {
+		region = storageZoneDefaultRegion
+	}
+
+	id := s.nextID()
+	sz := bunny.StorageZone{
+		ID:                 ptr.ToInt64(id),
+		UserID:             ptr.ToString(uuid.New().String()),
+		Name:               opts.Name,
+		Password:           ptr.ToString(uuid.New().String()),
+		ReadOnlyPassword:   ptr.ToString(uuid.New().String()),
+		Deleted:            ptr.ToBool(false),
+		StorageUsed:        ptr.ToInt64(0),
+		FilesStored:        ptr.ToInt64(0),
+		Region:             ptr.ToString(region),
+		ReplicationRegions: opts.ReplicationRegions,
+		StorageHostname:    ptr.ToString(storageZoneHostname(region)),
+	}
+
+	s.storageZones[id] = &sz
+
+	writeJSON(w, http.StatusCreated, &sz)
+}
+
+// storageZoneHostname returns the hostname of the storage API endpoint of
+// region.
+func storageZoneHostname(region string) string {
+	if region == storageZoneDefaultRegion {
+		return "storage.bunnycdn.com"
+	}
+
+	return strings.ToLower(region) + ".storage.bunnycdn.com"
+}
+
+func (s *Server) updateStorageZone(w http.ResponseWriter, r *http.Request, sz *bunny.StorageZone) {
+	var opts bunny.StorageZoneUpdateOptions
+
+	if !decodeBody(w, r, &opts) {
+		return
+	}
+
+	// bunny.net rejects 404 file paths that are not absolute
+	if opts.Custom404FilePath != nil && !strings.HasPrefix(*opts.Custom404FilePath, "/") {
+		writeAPIError(w, http.StatusBadRequest, "validation", "Custom404FilePath", "The Custom404FilePath must be an absolute path")
+		return
+	}
+
+	if opts.ReplicationRegions != nil {
+		sz.ReplicationRegions = opts.ReplicationRegions
+	}
+
+	writeNoContent(w)
+}
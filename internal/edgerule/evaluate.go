@@ -0,0 +1,253 @@
+// Package edgerule evaluates bunny.net edge rules offline against synthetic
+// requests.
+package edgerule
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+// Request is a synthetic request that edge rules are evaluated against.
+type Request struct {
+	// URL is the full URL of the request, including the scheme and
+	// hostname, e.g. https://cdn.example.com/img/logo.png?v=1
+	URL string
+	// Method is the HTTP method of the request.
+	Method string
+	// Headers are the HTTP headers of the request, the names are
+	// case-insensitive. Cookies are read from the Cookie header.
+	Headers map[string]string
+	// ResponseHeaders are the HTTP headers of the response, the names are
+	// case-insensitive.
+	ResponseHeaders map[string]string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country from that
+	// the request originates.
+	CountryCode string
+	// CountryStateCode is the ISO 3166-2 code of the state from that the
+	// request originates, e.g. US-CA.
+	CountryStateCode string
+	// RemoteIP is the IP address of the client.
+	RemoteIP string
+	// OriginRetryAttemptCount is the number of times the request to the
+	// origin was retried.
+	OriginRetryAttemptCount int
+	// RandomValue replaces the random number that bunny.net rolls for
+	// random chance triggers, to make the evaluation deterministic.
+	// It must be between 0 and 99, a random chance trigger with a
+	// percentage of N matches if RandomValue is lower than N.
+	RandomValue int
+}
+
+// Evaluate returns the edge rules that fire for req, in the order of rules.
+// Disabled edge rules never fire.
+func Evaluate(rules []*bunny.EdgeRule, req *Request) ([]*bunny.EdgeRule, error) {
+	var res []*bunny.EdgeRule
+
+	for i, rule := range rules {
+		if !ptr.GetBool(rule.Enabled) {
+			continue
+		}
+
+		matches, err := Match(rule, req)
+		if err != nil {
+			return nil, fmt.Errorf("edge rule %d: %w", i, err)
+		}
+
+		if matches {
+			res = append(res, rule)
+		}
+	}
+
+	return res, nil
+}
+
+// Match reports whether the triggers of rule match req, according to the
+// TriggerMatchingType of the rule:
+//   - any: at least one trigger matches,
+//   - all: every trigger matches,
+//   - none: no trigger matches.
+//
+// A trigger matches if its patterns match the value of req that is selected
+// by the trigger type, according to the PatternMatchingType of the trigger.
+// Patterns are compared via MatchWildcard, except for remote IP patterns that
+// are IP addresses or CIDRs and random chance patterns.
+// Whether the rule is enabled is not considered.
+func Match(rule *bunny.EdgeRule, req *Request) (bool, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return false, fmt.Errorf("parsing request url failed: %w", err)
+	}
+
+	results := make([]bool, 0, len(rule.Triggers))
+	for i, trigger := range rule.Triggers {
+		matches, err := matchTrigger(trigger, u, req)
+		if err != nil {
+			return false, fmt.Errorf("trigger %d: %w", i, err)
+		}
+
+		results = append(results, matches)
+	}
+
+	return combine(ptr.GetInt(rule.TriggerMatchingType), results)
+}
+
+// combine returns the result of applying the matching type to the results.
+func combine(matchingType int, results []bool) (bool, error) {
+	var matched int
+	for _, r := range results {
+		if r {
+			matched++
+		}
+	}
+
+	switch matchingType {
+	case bunny.MatchingTypeAny:
+		return matched > 0, nil
+	case bunny.MatchingTypeAll:
+		return matched == len(results), nil
+	case bunny.MatchingTypeNone:
+		return matched == 0, nil
+	default:
+		return false, fmt.Errorf("unsupported matching type: %d", matchingType)
+	}
+}
+
+func matchTrigger(trigger *bunny.EdgeRuleTrigger, u *url.URL, req *Request) (bool, error) {
+	if trigger.Type == nil {
+		return false, errors.New("trigger type is unset")
+	}
+
+	matchPattern := MatchWildcard
+
+	var value string
+	valueExists := true
+
+	switch *trigger.Type {
+	case bunny.EdgeRuleTriggerTypeURL:
+		value = req.URL
+
+	case bunny.EdgeRuleTriggerTypeRequestHeader:
+		value, valueExists = headerValue(req.Headers, ptr.GetString(trigger.Parameter1))
+
+	case bunny.EdgeRuleTriggerTypeResponseHeader:
+		value, valueExists = headerValue(req.ResponseHeaders, ptr.GetString(trigger.Parameter1))
+
+	case bunny.EdgeRuleTriggerTypeURLExtension:
+		value = strings.TrimPrefix(path.Ext(u.Path), ".")
+		matchPattern = func(pattern, s string) bool {
+			return MatchWildcard(strings.TrimPrefix(pattern, "."), s)
+		}
+
+	case bunny.EdgeRuleTriggerTypeCountryCode:
+		value = req.CountryCode
+
+	case bunny.EdgeRuleTriggerTypeRemoteIP:
+		value = req.RemoteIP
+		matchPattern = matchRemoteIP
+
+	case bunny.EdgeRuleTriggerTypeURLQueryString:
+		value = u.RawQuery
+
+	case bunny.EdgeRuleTriggerTypeRandomChance:
+		return matchRandomChance(trigger, req.RandomValue)
+
+	case bunny.EdgeRuleTriggerTypeStatusCode:
+		value = strconv.Itoa(req.StatusCode)
+
+	case bunny.EdgeRuleTriggerTypeRequestMethod:
+		value = req.Method
+
+	case bunny.EdgeRuleTriggerTypeCookieValue:
+		value, valueExists = cookieValue(req.Headers, ptr.GetString(trigger.Parameter1))
+
+	case bunny.EdgeRuleTriggerTypeCountryStateCode:
+		value = req.CountryStateCode
+
+	case bunny.EdgeRuleTriggerTypeOriginRetryAttemptCount:
+		value = strconv.Itoa(req.OriginRetryAttemptCount)
+
+	default:
+		return false, fmt.Errorf("unsupported trigger type: %d", *trigger.Type)
+	}
+
+	results := make([]bool, 0, len(trigger.PatternMatches))
+	for _, pattern := range trigger.PatternMatches {
+		// headers and cookies that do not exist do not match any
+		// pattern, not even *
+		results = append(results, valueExists && matchPattern(pattern, value))
+	}
+
+	return combine(ptr.GetInt(trigger.PatternMatchingType), results)
+}
+
+func headerValue(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+func cookieValue(headers map[string]string, name string) (string, bool) {
+	cookieHeader, exists := headerValue(headers, "Cookie")
+	if !exists {
+		return "", false
+	}
+
+	r := http.Request{Header: http.Header{"Cookie": []string{cookieHeader}}}
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return "", false
+	}
+
+	return cookie.Value, true
+}
+
+// matchRemoteIP matches ip against pattern. If pattern is a CIDR, it matches
+// if ip is in the network, if it is an IP address, it matches if they are
+// equal. Other patterns are matched as wildcards.
+func matchRemoteIP(pattern, ip string) bool {
+	addr := net.ParseIP(ip)
+
+	if _, network, err := net.ParseCIDR(pattern); err == nil {
+		return addr != nil && network.Contains(addr)
+	}
+
+	if patternAddr := net.ParseIP(pattern); patternAddr != nil {
+		return addr != nil && patternAddr.Equal(addr)
+	}
+
+	return MatchWildcard(pattern, ip)
+}
+
+// matchRandomChance evaluates a random chance trigger. The patterns are
+// percentages, a pattern matches if randomValue is lower than it.
+func matchRandomChance(trigger *bunny.EdgeRuleTrigger, randomValue int) (bool, error) {
+	results := make([]bool, 0, len(trigger.PatternMatches))
+
+	for _, pattern := range trigger.PatternMatches {
+		percentage, err := strconv.Atoi(pattern)
+		if err != nil {
+			return false, fmt.Errorf("random chance pattern %q is not an integer", pattern)
+		}
+
+		results = append(results, randomValue < percentage)
+	}
+
+	return combine(ptr.GetInt(trigger.PatternMatchingType), results)
+}
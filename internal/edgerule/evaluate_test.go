@@ -0,0 +1,276 @@
+package edgerule
+
+import (
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+func TestMatchWildcard(t *testing.T) {
+	testcases := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		{"*", "", true},
+		{"*", "https://example.com/", true},
+		{"", "", true},
+		{"", "a", false},
+		{"https://example.com/", "https://example.com/", true},
+		{"https://EXAMPLE.com/", "https://example.com/", true},
+		{"*/blog/*", "https://example.com/blog/post", true},
+		{"*/blog/*", "https://example.com/blog", false},
+		{"*.jpg", "https://example.com/a.jpg", true},
+		{"*.jpg", "https://example.com/a.jpg?v=1", false},
+		{"*.jpg*", "https://example.com/a.jpg?v=1", true},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "aXbYbZc", true},
+		{"a*b*c", "aXbYc", true},
+		{"a*b*c", "acb", false},
+		{"**", "x", true},
+		{"a?c", "abc", false},
+	}
+
+	for _, tc := range testcases {
+		if got := MatchWildcard(tc.pattern, tc.s); got != tc.want {
+			t.Errorf("MatchWildcard(%q, %q) = %t, expected %t", tc.pattern, tc.s, got, tc.want)
+		}
+	}
+}
+
+func trigger(triggerType, matchingType int, patterns ...string) *bunny.EdgeRuleTrigger {
+	return &bunny.EdgeRuleTrigger{
+		Type:                ptr.ToInt(triggerType),
+		PatternMatchingType: ptr.ToInt(matchingType),
+		PatternMatches:      patterns,
+	}
+}
+
+func rule(matchingType int, triggers ...*bunny.EdgeRuleTrigger) *bunny.EdgeRule {
+	return &bunny.EdgeRule{
+		Enabled:             ptr.ToBool(true),
+		ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeBlockRequest),
+		TriggerMatchingType: ptr.ToInt(matchingType),
+		Triggers:            triggers,
+	}
+}
+
+func TestMatch(t *testing.T) {
+	req := Request{
+		URL:    "https://cdn.example.com/img/logo.PNG?v=2",
+		Method: "GET",
+		Headers: map[string]string{
+			"User-Agent": "curl/8.0",
+			"cookie":     "session=abc123; theme=dark",
+		},
+		ResponseHeaders:         map[string]string{"Content-Type": "image/png"},
+		StatusCode:              404,
+		CountryCode:             "DE",
+		CountryStateCode:        "DE-BY",
+		RemoteIP:                "10.1.2.3",
+		OriginRetryAttemptCount: 1,
+		RandomValue:             30,
+	}
+
+	headerTrigger := func(triggerType int, name string, patterns ...string) *bunny.EdgeRuleTrigger {
+		tr := trigger(triggerType, bunny.MatchingTypeAny, patterns...)
+		tr.Parameter1 = &name
+		return tr
+	}
+
+	testcases := []struct {
+		name string
+		rule *bunny.EdgeRule
+		want bool
+	}{
+		{
+			name: "url wildcard",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURL, bunny.MatchingTypeAny, "*/img/*")),
+			want: true,
+		},
+		{
+			name: "url pattern matching type all",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURL, bunny.MatchingTypeAll, "*/img/*", "*.gif*")),
+			want: false,
+		},
+		{
+			name: "url pattern matching type none",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURL, bunny.MatchingTypeNone, "*/css/*", "*.gif*")),
+			want: true,
+		},
+		{
+			name: "url extension with and without dot",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURLExtension, bunny.MatchingTypeAll, "png", ".png")),
+			want: true,
+		},
+		{
+			name: "query string",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURLQueryString, bunny.MatchingTypeAny, "v=*")),
+			want: true,
+		},
+		{
+			name: "request header name is case-insensitive",
+			rule: rule(bunny.MatchingTypeAll, headerTrigger(bunny.EdgeRuleTriggerTypeRequestHeader, "user-agent", "curl/*")),
+			want: true,
+		},
+		{
+			name: "missing request header does not match wildcard",
+			rule: rule(bunny.MatchingTypeAll, headerTrigger(bunny.EdgeRuleTriggerTypeRequestHeader, "Referer", "*")),
+			want: false,
+		},
+		{
+			name: "response header",
+			rule: rule(bunny.MatchingTypeAll, headerTrigger(bunny.EdgeRuleTriggerTypeResponseHeader, "content-type", "image/*")),
+			want: true,
+		},
+		{
+			name: "cookie value",
+			rule: rule(bunny.MatchingTypeAll, headerTrigger(bunny.EdgeRuleTriggerTypeCookieValue, "theme", "dark")),
+			want: true,
+		},
+		{
+			name: "missing cookie",
+			rule: rule(bunny.MatchingTypeAll, headerTrigger(bunny.EdgeRuleTriggerTypeCookieValue, "admin", "*")),
+			want: false,
+		},
+		{
+			name: "country code is case-insensitive",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeCountryCode, bunny.MatchingTypeAny, "de", "DK")),
+			want: true,
+		},
+		{
+			name: "country state code",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeCountryStateCode, bunny.MatchingTypeAny, "DE-*")),
+			want: true,
+		},
+		{
+			name: "remote ip in cidr",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeRemoteIP, bunny.MatchingTypeAny, "10.0.0.0/8")),
+			want: true,
+		},
+		{
+			name: "remote ip not equal",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeRemoteIP, bunny.MatchingTypeAny, "10.1.2.4", "192.168.0.0/16")),
+			want: false,
+		},
+		{
+			name: "status code wildcard",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeStatusCode, bunny.MatchingTypeAny, "4*")),
+			want: true,
+		},
+		{
+			name: "request method",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeRequestMethod, bunny.MatchingTypeNone, "POST", "PUT")),
+			want: true,
+		},
+		{
+			name: "origin retry attempt count",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeOriginRetryAttemptCount, bunny.MatchingTypeAny, "1")),
+			want: true,
+		},
+		{
+			name: "random chance above random value",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeRandomChance, bunny.MatchingTypeAny, "31")),
+			want: true,
+		},
+		{
+			name: "random chance equal to random value",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeRandomChance, bunny.MatchingTypeAny, "30")),
+			want: false,
+		},
+		{
+			name: "rule matching type any",
+			rule: rule(bunny.MatchingTypeAny,
+				trigger(bunny.EdgeRuleTriggerTypeCountryCode, bunny.MatchingTypeAny, "US"),
+				trigger(bunny.EdgeRuleTriggerTypeRequestMethod, bunny.MatchingTypeAny, "GET"),
+			),
+			want: true,
+		},
+		{
+			name: "rule matching type all",
+			rule: rule(bunny.MatchingTypeAll,
+				trigger(bunny.EdgeRuleTriggerTypeCountryCode, bunny.MatchingTypeAny, "US"),
+				trigger(bunny.EdgeRuleTriggerTypeRequestMethod, bunny.MatchingTypeAny, "GET"),
+			),
+			want: false,
+		},
+		{
+			name: "rule matching type none",
+			rule: rule(bunny.MatchingTypeNone,
+				trigger(bunny.EdgeRuleTriggerTypeCountryCode, bunny.MatchingTypeAny, "US"),
+				trigger(bunny.EdgeRuleTriggerTypeRequestMethod, bunny.MatchingTypeAny, "POST"),
+			),
+			want: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Match(tc.rule, &req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("Match returned %t, expected %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	req := Request{URL: "http://example.com/", Method: "GET"}
+
+	forceSSL := rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURL, bunny.MatchingTypeAny, "http://*"))
+	forceSSL.ActionType = ptr.ToInt(bunny.EdgeRuleActionTypeForceSSL)
+
+	disabled := rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURL, bunny.MatchingTypeAny, "*"))
+	disabled.Enabled = ptr.ToBool(false)
+
+	notMatching := rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeRequestMethod, bunny.MatchingTypeAny, "POST"))
+
+	block := rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURL, bunny.MatchingTypeAny, "*example.com*"))
+
+	fired, err := Evaluate([]*bunny.EdgeRule{forceSSL, disabled, notMatching, block}, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fired) != 2 || fired[0] != forceSSL || fired[1] != block {
+		t.Errorf("expected the force ssl and block rule to fire, got: %+v", fired)
+	}
+}
+
+func TestEvaluate_errors(t *testing.T) {
+	testcases := []struct {
+		name string
+		rule *bunny.EdgeRule
+		req  Request
+	}{
+		{
+			name: "unsupported trigger type",
+			rule: rule(bunny.MatchingTypeAll, trigger(1000, bunny.MatchingTypeAny, "*")),
+			req:  Request{URL: "https://example.com/"},
+		},
+		{
+			name: "invalid random chance",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeRandomChance, bunny.MatchingTypeAny, "half")),
+			req:  Request{URL: "https://example.com/"},
+		},
+		{
+			name: "invalid url",
+			rule: rule(bunny.MatchingTypeAll, trigger(bunny.EdgeRuleTriggerTypeURL, bunny.MatchingTypeAny, "*")),
+			req:  Request{URL: "https://example.com/%zz"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Evaluate([]*bunny.EdgeRule{tc.rule}, &tc.req); err == nil {
+				t.Fatal("evaluation succeeded, expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package edgerule
+
+import "strings"
+
+// MatchWildcard reports whether s matches pattern.
+// The comparison is case-insensitive. The wildcard * in pattern matches any
+// sequence of characters, including an empty one. All other characters only
+// match themselves.
+func MatchWildcard(pattern, s string) bool {
+	pattern = strings.ToLower(pattern)
+	s = strings.ToLower(s)
+
+	// p and i are the current positions in pattern and s.
+	// starIdx is the position of the last * in pattern that was
+	// processed and matchIdx the position in s from that on the * matches,
+	// they are used to backtrack when the remaining pattern does not match.
+	p, i := 0, 0
+	starIdx, matchIdx := -1, 0
+
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			starIdx = p
+			matchIdx = i
+			p++
+
+		case p < len(pattern) && pattern[p] == s[i]:
+			p++
+			i++
+
+		case starIdx != -1:
+			matchIdx++
+			i = matchIdx
+			p = starIdx + 1
+
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	bunny "github.com/simplesurance/bunny-go"
+
+	"github.com/simplesurance/terraform-provider-bunny/internal/edgerule"
+)
+
+const (
+	keyEdgeRuleEvaluateEdgeRule = "edgerule"
+	keyEdgeRuleEvaluateRequest  = "request"
+	keyEdgeRuleEvaluateActions  = "actions"
+)
+
+const (
+	keyEvaluateRequestURL                     = "url"
+	keyEvaluateRequestMethod                  = "method"
+	keyEvaluateRequestHeaders                 = "headers"
+	keyEvaluateRequestResponseHeaders         = "response_headers"
+	keyEvaluateRequestStatusCode              = "status_code"
+	keyEvaluateRequestCountryCode             = "country_code"
+	keyEvaluateRequestCountryStateCode        = "country_state_code"
+	keyEvaluateRequestRemoteIP                = "remote_ip"
+	keyEvaluateRequestOriginRetryAttemptCount = "origin_retry_attempt_count"
+	keyEvaluateRequestRandomValue             = "random_value"
+)
+
+const keyEvaluateActionIndex = "index"
+
+func dataSourceEdgeRuleEvaluate() *schema.Resource {
+	edgeRuleSchema := resourcePullZoneEdgeRulesEdgeRule().Schema
+	delete(edgeRuleSchema, keyEdgeRuleGUID)
+
+	return &schema.Resource{
+		Description: "Evaluates Edge Rules offline against a synthetic request and returns the actions that would be executed. " +
+			"It does not access the bunny.net API, the matching of wildcards, triggers and matching types is emulated. " +
+			"It allows testing Edge Rules in `terraform test` files and `check` blocks before they are applied.",
+		ReadContext: dataSourceEdgeRuleEvaluateRead,
+		Schema: map[string]*schema.Schema{
+			keyEdgeRuleEvaluateEdgeRule: {
+				Type:        schema.TypeList,
+				Description: "The Edge Rules that are evaluated, in the order in that they are executed. They have the same attributes as the edgerule blocks of bunny_pullzone_edgerules.",
+				Optional:    true,
+				Elem:        &schema.Resource{Schema: edgeRuleSchema},
+			},
+			keyEdgeRuleEvaluateRequest: {
+				Type:        schema.TypeList,
+				Description: "The request that the Edge Rules are evaluated against.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keyEvaluateRequestURL: {
+							Type:             schema.TypeString,
+							Description:      "The full URL of the request, including the scheme and hostname.",
+							Required:         true,
+							ValidateDiagFunc: validateEdgeRuleActionURL,
+						},
+						keyEvaluateRequestMethod: {
+							Type:        schema.TypeString,
+							Description: "The HTTP method of the request.",
+							Optional:    true,
+							Default:     "GET",
+						},
+						keyEvaluateRequestHeaders: {
+							Type:        schema.TypeMap,
+							Description: "The HTTP headers of the request. Cookies are read from the `Cookie` header.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						keyEvaluateRequestResponseHeaders: {
+							Type:        schema.TypeMap,
+							Description: "The HTTP headers of the response.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						keyEvaluateRequestStatusCode: {
+							Type:             schema.TypeInt,
+							Description:      "The HTTP status code of the response.",
+							Optional:         true,
+							Default:          200,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(100, 599)),
+						},
+						keyEvaluateRequestCountryCode: {
+							Type:        schema.TypeString,
+							Description: "The ISO 3166-1 alpha-2 code of the country from that the request originates.",
+							Optional:    true,
+						},
+						keyEvaluateRequestCountryStateCode: {
+							Type:        schema.TypeString,
+							Description: "The ISO 3166-2 code of the state from that the request originates, e.g. US-CA.",
+							Optional:    true,
+						},
+						keyEvaluateRequestRemoteIP: {
+							Type:             schema.TypeString,
+							Description:      "The IP address of the client.",
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+						},
+						keyEvaluateRequestOriginRetryAttemptCount: {
+							Type:        schema.TypeInt,
+							Description: "The number of times the request to the origin was retried.",
+							Optional:    true,
+						},
+						keyEvaluateRequestRandomValue: {
+							Type: schema.TypeInt,
+							Description: "Replaces the random number that bunny.net rolls for `random_chance` triggers. " +
+								"A `random_chance` trigger with a percentage of N matches if the value is lower than N. " +
+								"Required if an Edge Rule has a `random_chance` trigger.",
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 99)),
+						},
+					},
+				},
+			},
+			keyEdgeRuleEvaluateActions: {
+				Type:        schema.TypeList,
+				Description: "The actions of the Edge Rules that match the request, in the order in that they are executed. Disabled Edge Rules never match.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keyEvaluateActionIndex: {
+							Type:        schema.TypeInt,
+							Description: "The index of the Edge Rule in the edgerule list.",
+							Computed:    true,
+						},
+						keyEdgeRuleDescription: {
+							Type:        schema.TypeString,
+							Description: "The description of the Edge Rule.",
+							Computed:    true,
+						},
+						keyEdgeRuleActionType: {
+							Type:        schema.TypeString,
+							Description: "The action type of the Edge Rule.",
+							Computed:    true,
+						},
+						keyEdgeRuleActionParameter1: {
+							Type:        schema.TypeString,
+							Description: "The Action parameter 1, derived from the action block of the Edge Rule.",
+							Computed:    true,
+						},
+						keyEdgeRuleActionParameter2: {
+							Type:        schema.TypeString,
+							Description: "The Action parameter 2, derived from the action block of the Edge Rule.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEdgeRuleEvaluateRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	var rules []*bunny.EdgeRule
+
+	if err := validateEdgeRuleListTriggerPatterns(d.GetRawConfig(), keyEdgeRuleEvaluateEdgeRule); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for i, elem := range d.Get(keyEdgeRuleEvaluateEdgeRule).([]interface{}) {
+		rule, err := dataSourceEdgeRuleEvaluateRuleFromElem(i, elem)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := validateEdgeRuleEvaluateRandomValue(d, rules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := edgeRuleEvaluateRequestFromResource(d)
+
+	fired, err := edgerule.Evaluate(rules, req)
+	if err != nil {
+		return diagsErrFromErr("evaluating edge rules failed", err)
+	}
+
+	ruleIdx := make(map[*bunny.EdgeRule]int, len(rules))
+	for i, rule := range rules {
+		ruleIdx[rule] = i
+	}
+
+	actions := make([]map[string]interface{}, 0, len(fired))
+	for _, rule := range fired {
+		actionType, err := intStrMapGet(edgeRuleActionTypesInt, rule.ActionType)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		actions = append(actions, map[string]interface{}{
+			keyEvaluateActionIndex:      ruleIdx[rule],
+			keyEdgeRuleDescription:      ptr.GetString(rule.Description),
+			keyEdgeRuleActionType:       actionType,
+			keyEdgeRuleActionParameter1: ptr.GetString(rule.ActionParameter1),
+			keyEdgeRuleActionParameter2: ptr.GetString(rule.ActionParameter2),
+		})
+	}
+
+	if err := d.Set(keyEdgeRuleEvaluateActions, actions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%+v %+v", req, actions))))
+
+	return nil
+}
+
+// dataSourceEdgeRuleEvaluateRuleFromElem validates the action of the edgerule
+// element with index i and converts it to the API type.
+// The data source has no CustomizeDiff, the validations of
+// bunny_pullzone_edgerules are run in dataSourceEdgeRuleEvaluateRead instead.
+func dataSourceEdgeRuleEvaluateRuleFromElem(i int, elem interface{}) (*bunny.EdgeRule, error) {
+	m := structureFromElem([]interface{}{elem})
+
+	if err := validateEdgeRuleAction(m, true); err != nil {
+		return nil, fmt.Errorf("%s.%d: %w", keyEdgeRuleEvaluateEdgeRule, i, err)
+	}
+
+	opts, err := edgeRuleExpand(nil, m)
+	if err != nil {
+		return nil, fmt.Errorf("%s.%d: %w", keyEdgeRuleEvaluateEdgeRule, i, err)
+	}
+
+	return &bunny.EdgeRule{
+		Enabled:             opts.Enabled,
+		ActionType:          opts.ActionType,
+		ActionParameter1:    opts.ActionParameter1,
+		ActionParameter2:    opts.ActionParameter2,
+		Triggers:            opts.Triggers,
+		TriggerMatchingType: opts.TriggerMatchingType,
+		Description:         opts.Description,
+	}, nil
+}
+
+// validateEdgeRuleEvaluateRandomValue ensures that random_value is set if one
+// of the rules has a random_chance trigger. Otherwise the result would depend
+// on the zero value of random_value instead of a value that was chosen.
+func validateEdgeRuleEvaluateRandomValue(d *schema.ResourceData, rules []*bunny.EdgeRule) error {
+	// GetOkExists is required to distinguish between an unset
+	// random_value and a random_value of 0
+	//nolint:staticcheck // see above
+	if _, ok := d.GetOkExists(keyEdgeRuleEvaluateRequest + ".0." + keyEvaluateRequestRandomValue); ok {
+		return nil
+	}
+
+	for i, rule := range rules {
+		for _, trigger := range rule.Triggers {
+			if ptr.GetInt(trigger.Type) == bunny.EdgeRuleTriggerTypeRandomChance {
+				return fmt.Errorf("%s.0.%s must be set, %s.%d has a random_chance trigger",
+					keyEdgeRuleEvaluateRequest, keyEvaluateRequestRandomValue, keyEdgeRuleEvaluateEdgeRule, i,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func edgeRuleEvaluateRequestFromResource(d *schema.ResourceData) *edgerule.Request {
+	m := structureFromResource(d, keyEdgeRuleEvaluateRequest)
+
+	return &edgerule.Request{
+		URL:                     m.getStr(keyEvaluateRequestURL),
+		Method:                  m.getStr(keyEvaluateRequestMethod),
+		Headers:                 strMapFromInterfaceMap(m[keyEvaluateRequestHeaders]),
+		ResponseHeaders:         strMapFromInterfaceMap(m[keyEvaluateRequestResponseHeaders]),
+		StatusCode:              m[keyEvaluateRequestStatusCode].(int),
+		CountryCode:             m.getStr(keyEvaluateRequestCountryCode),
+		CountryStateCode:        m.getStr(keyEvaluateRequestCountryStateCode),
+		RemoteIP:                m.getStr(keyEvaluateRequestRemoteIP),
+		OriginRetryAttemptCount: m[keyEvaluateRequestOriginRetryAttemptCount].(int),
+		RandomValue:             m[keyEvaluateRequestRandomValue].(int),
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func edgeRuleEvaluateTestRule(description, triggerType string, patterns ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		keyEdgeRuleDescription:         description,
+		keyEdgeRuleTriggerMatchingType: "all",
+		keyEdgeRuleTriggers: []interface{}{
+			map[string]interface{}{
+				keyEdgeRuleTriggerType:                triggerType,
+				keyEdgeRuleTriggerPatternMatchingType: "any",
+				keyEdgeRuleTriggerPatternMatches:      patterns,
+			},
+		},
+	}
+}
+
+func TestEdgeRuleEvaluateDataSource(t *testing.T) {
+	forceSSL := edgeRuleEvaluateTestRule("https", "url", "http://*")
+	forceSSL[keyEdgeRuleActionType] = "force_ssl"
+
+	redirect := edgeRuleEvaluateTestRule("blog", "url", "*/blog/*")
+	redirect["redirect"] = []interface{}{
+		map[string]interface{}{keyEdgeRuleActionURL: "https://blog.example.com{{path}}", keyEdgeRuleActionStatusCode: 302},
+	}
+
+	disabled := edgeRuleEvaluateTestRule("disabled", "url", "*")
+	disabled[keyEdgeRuleActionType] = "block_request"
+	disabled[keyEdgeRuleEnabled] = false
+
+	blockCountry := edgeRuleEvaluateTestRule("block country", "country_code", "KP")
+	blockCountry[keyEdgeRuleActionType] = "block_request"
+
+	d := schema.TestResourceDataRaw(t, dataSourceEdgeRuleEvaluate().Schema, map[string]interface{}{
+		keyEdgeRuleEvaluateEdgeRule: []interface{}{forceSSL, redirect, disabled, blockCountry},
+		keyEdgeRuleEvaluateRequest: []interface{}{
+			map[string]interface{}{
+				keyEvaluateRequestURL:         "http://cdn.example.com/blog/post",
+				keyEvaluateRequestCountryCode: "DE",
+			},
+		},
+	})
+
+	if diags := dataSourceEdgeRuleEvaluateRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("read failed: %+v", diags)
+	}
+
+	if d.Id() == "" {
+		t.Error("id is empty")
+	}
+
+	actions := d.Get(keyEdgeRuleEvaluateActions).([]interface{})
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got: %+v", actions)
+	}
+
+	first := actions[0].(map[string]interface{})
+	if first[keyEvaluateActionIndex] != 0 || first[keyEdgeRuleActionType] != "force_ssl" {
+		t.Errorf("unexpected first action: %+v", first)
+	}
+
+	second := actions[1].(map[string]interface{})
+	if second[keyEvaluateActionIndex] != 1 ||
+		second[keyEdgeRuleDescription] != "blog" ||
+		second[keyEdgeRuleActionType] != "redirect" ||
+		second[keyEdgeRuleActionParameter1] != "https://blog.example.com{{path}}" ||
+		second[keyEdgeRuleActionParameter2] != "302" {
+		t.Errorf("unexpected second action: %+v", second)
+	}
+}
+
+func TestEdgeRuleEvaluateDataSource_invalidEdgeRule(t *testing.T) {
+	rule := edgeRuleEvaluateTestRule("invalid", "country_code", "de")
+	rule[keyEdgeRuleActionType] = "block_request"
+
+	d := testResourceDataCreate(t, dataSourceEdgeRuleEvaluate(), map[string]interface{}{
+		keyEdgeRuleEvaluateEdgeRule: []interface{}{rule},
+		keyEdgeRuleEvaluateRequest: []interface{}{
+			map[string]interface{}{keyEvaluateRequestURL: "https://cdn.example.com/"},
+		},
+	})
+
+	diags := dataSourceEdgeRuleEvaluateRead(context.Background(), d, nil)
+	if !diags.HasError() {
+		t.Fatal("read succeeded, expected an error for the invalid country code")
+	}
+
+	wantPrefix := keyEdgeRuleEvaluateEdgeRule + ".0." + keyEdgeRuleTriggers + ".0." + keyEdgeRuleTriggerPatternMatches + ".0:"
+	if !strings.HasPrefix(diags[0].Summary, wantPrefix) {
+		t.Errorf("error does not start with %q: %s", wantPrefix, diags[0].Summary)
+	}
+}
+
+func TestEdgeRuleEvaluateDataSource_randomValue(t *testing.T) {
+	rule := edgeRuleEvaluateTestRule("sample", "random_chance", "30")
+	rule[keyEdgeRuleActionType] = "block_request"
+
+	testcases := []struct {
+		name          string
+		request       map[string]interface{}
+		wantErr       bool
+		expectedMatch bool
+	}{
+		{
+			name:    "unset",
+			request: map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:          "zero",
+			request:       map[string]interface{}{keyEvaluateRequestRandomValue: 0},
+			expectedMatch: true,
+		},
+		{
+			name:    "above percentage",
+			request: map[string]interface{}{keyEvaluateRequestRandomValue: 30},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.request[keyEvaluateRequestURL] = "https://cdn.example.com/"
+
+			d := schema.TestResourceDataRaw(t, dataSourceEdgeRuleEvaluate().Schema, map[string]interface{}{
+				keyEdgeRuleEvaluateEdgeRule: []interface{}{rule},
+				keyEdgeRuleEvaluateRequest:  []interface{}{tc.request},
+			})
+
+			diags := dataSourceEdgeRuleEvaluateRead(context.Background(), d, nil)
+			if tc.wantErr {
+				if !diags.HasError() {
+					t.Fatal("read succeeded, expected an error for the unset random_value")
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("read failed: %+v", diags)
+			}
+
+			if matched := len(d.Get(keyEdgeRuleEvaluateActions).([]interface{})) == 1; matched != tc.expectedMatch {
+				t.Errorf("edge rule matched: %t, expected: %t", matched, tc.expectedMatch)
+			}
+		})
+	}
+}
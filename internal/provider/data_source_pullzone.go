@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const (
+	keyID        = "id"
+	keyHostnames = "hostnames"
+	keyEdgeRules = "edge_rules"
+
+	keyEdgeRuleGUID = "guid"
+)
+
+func dataSourcePullZone() *schema.Resource {
+	s := dataSourceSchemaFromResourceSchema(resourcePullZone().Schema)
+	delete(s, keyLastUpdated)
+
+	s[keyID] = &schema.Schema{
+		Type:             schema.TypeString,
+		Description:      "The ID of the Pull Zone.",
+		Optional:         true,
+		Computed:         true,
+		ExactlyOneOf:     []string{keyID, keyName},
+		ValidateDiagFunc: validateIsIDStr,
+	}
+	s[keyName] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The name of the Pull Zone.",
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{keyID, keyName},
+	}
+
+	s[keyHostnames] = dataSourcePullZoneHostnamesSchema()
+
+	edgeRuleSchema := dataSourceSchemaFromResourceSchema(resourceEdgeRule().Schema)
+	delete(edgeRuleSchema, keyEdgeRulePullZoneID)
+	edgeRuleSchema[keyEdgeRuleGUID] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The unique identifier of the edge rule.",
+		Computed:    true,
+	}
+	s[keyEdgeRules] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The edge rules of the Pull Zone.",
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: edgeRuleSchema},
+	}
+
+	return &schema.Resource{
+		Description: "Retrieves a Pull Zone by its ID or name.",
+		ReadContext: dataSourcePullZoneRead,
+		Schema:      s,
+	}
+}
+
+func dataSourcePullZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*client)
+
+	var pz *bunny.PullZone
+
+	if idStr := d.Get(keyID).(string); idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return diagsErrFromErr(fmt.Sprintf("%s is not an integer", keyID), err)
+		}
+
+		pz, err = clt.pullZoneCache.Get(ctx, id)
+		if err != nil {
+			return diagsErrFromErr("could not retrieve pull zone", err)
+		}
+	} else {
+		var err error
+
+		pz, err = findPullZoneByName(ctx, clt, d.Get(keyName).(string))
+		if err != nil {
+			return diagsErrFromErr("could not find pull zone", err)
+		}
+	}
+
+	if err := pullZoneToResource(pz, d); err != nil {
+		return diagsErrFromErr("converting api type to data source failed", err)
+	}
+
+	origin, err := originFlatten(pz)
+	if err != nil {
+		return diagsErrFromErr("converting origin failed", err)
+	}
+	if err := d.Set(keyOrigin, origin); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(keyHostnames, dataSourcePullZoneHostnamesFlatten(pz)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	edgeRules := make([]map[string]interface{}, 0, len(pz.EdgeRules))
+	for _, edgeRule := range pz.EdgeRules {
+		m, err := edgeRuleFlatten(edgeRule)
+		if err != nil {
+			return diagsErrFromErr("converting edge rule failed", err)
+		}
+		m[keyEdgeRuleGUID] = edgeRule.GUID
+
+		edgeRules = append(edgeRules, m)
+	}
+	if err := d.Set(keyEdgeRules, edgeRules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func dataSourcePullZoneHostnamesSchema() *schema.Schema {
+	hostnameSchema := dataSourceSchemaFromResourceSchema(resourceHostname().Schema)
+	delete(hostnameSchema, keyHostnamePullZoneID)
+	delete(hostnameSchema, keyHostnameLoadFreeCertificate)
+	delete(hostnameSchema, keyHostnameCertificate)
+	hostnameSchema[keyID] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The ID of the hostname.",
+		Computed:    true,
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The hostnames of the Pull Zone.",
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: hostnameSchema},
+	}
+}
+
+func dataSourcePullZoneHostnamesFlatten(pz *bunny.PullZone) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(pz.Hostnames))
+
+	for _, hostname := range pz.Hostnames {
+		m := hostnameFlatten(pz, hostname)
+		m[keyID] = hostname.ID
+
+		res = append(res, m)
+	}
+
+	return res
+}
+
+// listPullZones retrieves all pull zones, by requesting all pages from the
+// list API endpoint.
+func listPullZones(ctx context.Context, clt *client) ([]*bunny.PullZone, error) {
+	var res []*bunny.PullZone
+
+	for page := int32(1); ; page++ {
+		pullZones, err := clt.PullZone.List(ctx, &bunny.PaginationOptions{
+			Page:    page,
+			PerPage: 1000,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing pull zones failed: %w", err)
+		}
+
+		res = append(res, pullZones.Items...)
+
+		if pullZones.HasMoreItems == nil || !*pullZones.HasMoreItems {
+			return res, nil
+		}
+	}
+}
+
+// findPullZoneByName returns the pull zone with the given name.
+func findPullZoneByName(ctx context.Context, clt *client, name string) (*bunny.PullZone, error) {
+	pullZones, err := listPullZones(ctx, clt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pz := range pullZones {
+		if pz.Name == nil || *pz.Name != name {
+			continue
+		}
+
+		if pz.ID == nil {
+			return nil, errors.New("found pull zone with matching name but id is nil")
+		}
+
+		// the list endpoint might not return all fields, retrieve the
+		// complete pull zone
+		return clt.pullZoneCache.Get(ctx, *pz.ID)
+	}
+
+	return nil, fmt.Errorf("pull zone with name %q: %w", name, errNotFound)
+}
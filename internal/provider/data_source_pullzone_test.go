@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+func TestFakeAPIPullZoneDataSource(t *testing.T) {
+	ctx := context.Background()
+	_, meta := newFakeAPIProvider(t)
+	clt := meta.(*client)
+
+	// create some pull zones that should not be found
+	for i := 0; i < 3; i++ {
+		newFakeAPIPullZone(t, meta)
+	}
+
+	pzID := newFakeAPIPullZone(t, meta)
+	pz, err := clt.PullZone.Get(ctx, pzID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hostname := randHostname()
+	if err := clt.PullZone.AddCustomHostname(ctx, pzID, &bunny.AddCustomHostnameOptions{Hostname: &hostname}); err != nil {
+		t.Fatal(err)
+	}
+
+	err = clt.PullZone.AddOrUpdateEdgeRule(ctx, pzID, &bunny.AddOrUpdateEdgeRuleOptions{
+		ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeForceSSL),
+		TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+		Triggers: []*bunny.EdgeRuleTrigger{{
+			Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURL),
+			PatternMatches:      []string{"*"},
+			PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+		}},
+		Description: ptr.ToString("force ssl"),
+		Enabled:     ptr.ToBool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{
+			name:   "by id",
+			config: map[string]interface{}{keyID: strconv.FormatInt(pzID, 10)},
+		},
+		{
+			name:   "by name",
+			config: map[string]interface{}{keyName: *pz.Name},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourcePullZone().Schema, tc.config)
+
+			if diags := dataSourcePullZoneRead(ctx, d, meta); diags.HasError() {
+				t.Fatalf("read failed: %+v", diags)
+			}
+
+			if d.Id() != strconv.FormatInt(pzID, 10) {
+				t.Errorf("got pull zone with id %q, expected %d", d.Id(), pzID)
+			}
+
+			if name := d.Get(keyName).(string); name != *pz.Name {
+				t.Errorf("got pull zone with name %q, expected %q", name, *pz.Name)
+			}
+
+			if cname := d.Get(keyCnameDomain).(string); cname != *pz.CnameDomain {
+				t.Errorf("unexpected %s: %q", keyCnameDomain, cname)
+			}
+
+			if originType := d.Get(keyOrigin + ".0." + keyOriginType).(string); originType != originTypeURL {
+				t.Errorf("unexpected %s.%s: %q", keyOrigin, keyOriginType, originType)
+			}
+
+			if cnt := d.Get(keyHostnames + ".#").(int); cnt != 2 {
+				t.Fatalf("expected 2 hostnames, got %d", cnt)
+			}
+
+			if h := d.Get(keyHostnames + ".1." + keyHostnameHostname).(string); h != hostname {
+				t.Errorf("expected hostname %q, got %q", hostname, h)
+			}
+
+			if cnt := d.Get(keyEdgeRules + ".#").(int); cnt != 1 {
+				t.Fatalf("expected 1 edge rule, got %d", cnt)
+			}
+
+			if actionType := d.Get(keyEdgeRules + ".0." + keyEdgeRuleActionType).(string); actionType != "force_ssl" {
+				t.Errorf("unexpected edge rule action type: %q", actionType)
+			}
+
+			if guid := d.Get(keyEdgeRules + ".0." + keyEdgeRuleGUID).(string); guid == "" {
+				t.Error("edge rule guid is empty")
+			}
+		})
+	}
+}
+
+func TestFakeAPIPullZoneDataSource_nameNotFound(t *testing.T) {
+	_, meta := newFakeAPIProvider(t)
+	newFakeAPIPullZone(t, meta)
+
+	d := schema.TestResourceDataRaw(t, dataSourcePullZone().Schema, map[string]interface{}{
+		keyName: "does-not-exist",
+	})
+
+	if diags := dataSourcePullZoneRead(context.Background(), d, meta); !diags.HasError() {
+		t.Fatal("read of non-existing pull zone succeeded")
+	}
+}
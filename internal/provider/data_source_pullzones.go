@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const (
+	keyNamePrefix = "name_prefix"
+	keyNameRegex  = "name_regex"
+	keyPullZones  = "pull_zones"
+)
+
+func dataSourcePullZones() *schema.Resource {
+	resourceSchema := resourcePullZone().Schema
+
+	pullZoneSchema := map[string]*schema.Schema{
+		keyID: {
+			Type:        schema.TypeInt,
+			Description: "The ID of the Pull Zone.",
+			Computed:    true,
+		},
+		keyHostnames: dataSourcePullZoneHostnamesSchema(),
+	}
+	for _, k := range []string{keyName, keyType, keyEnabled, keyStorageZoneID, keyOriginURL, keyCnameDomain} {
+		pullZoneSchema[k] = dataSourceSchemaFromResourceSchemaAttr(resourceSchema[k])
+	}
+
+	return &schema.Resource{
+		Description: "Retrieves all Pull Zones of the account, optionally filtered by the specified attributes.",
+		ReadContext: dataSourcePullZonesRead,
+		Schema: map[string]*schema.Schema{
+			keyNamePrefix: {
+				Type:        schema.TypeString,
+				Description: "Only return Pull Zones with names that start with the prefix.",
+				Optional:    true,
+			},
+			keyNameRegex: {
+				Type:             schema.TypeString,
+				Description:      "Only return Pull Zones with names that match the regular expression.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			keyType: {
+				Type:             schema.TypeInt,
+				Description:      "Only return Pull Zones of the type. Standard = 0, Volume = 1.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 1)),
+			},
+			keyStorageZoneID: {
+				Type:        schema.TypeInt,
+				Description: "Only return Pull Zones that are linked to the storage zone.",
+				Optional:    true,
+			},
+			keyEnabled: {
+				Type:        schema.TypeBool,
+				Description: "Only return Pull Zones that are enabled or disabled.",
+				Optional:    true,
+			},
+			keyPullZones: {
+				Type:        schema.TypeList,
+				Description: "The Pull Zones that match the filters, ordered by their ID.",
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: pullZoneSchema},
+			},
+		},
+	}
+}
+
+// pullZoneFilter matches pull zones by their attributes, unset fields match
+// all pull zones.
+type pullZoneFilter struct {
+	namePrefix    string
+	nameRegex     *regexp.Regexp
+	pzType        *int
+	storageZoneID *int64
+	enabled       *bool
+}
+
+func pullZoneFilterFromResource(d *schema.ResourceData) (*pullZoneFilter, error) {
+	var res pullZoneFilter
+
+	res.namePrefix = d.Get(keyNamePrefix).(string)
+
+	if expr := d.Get(keyNameRegex).(string); expr != "" {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		res.nameRegex = re
+	}
+
+	// GetOkExists is required to distinguish between unset attributes and
+	// attributes that are set to their zero value
+	//nolint:staticcheck // see above
+	if v, ok := d.GetOkExists(keyType); ok {
+		pzType := v.(int)
+		res.pzType = &pzType
+	}
+
+	//nolint:staticcheck // see above
+	if v, ok := d.GetOkExists(keyStorageZoneID); ok {
+		storageZoneID := int64(v.(int))
+		res.storageZoneID = &storageZoneID
+	}
+
+	//nolint:staticcheck // see above
+	if v, ok := d.GetOkExists(keyEnabled); ok {
+		enabled := v.(bool)
+		res.enabled = &enabled
+	}
+
+	return &res, nil
+}
+
+func (f *pullZoneFilter) matches(pz *bunny.PullZone) bool {
+	name := ""
+	if pz.Name != nil {
+		name = *pz.Name
+	}
+
+	if !strings.HasPrefix(name, f.namePrefix) {
+		return false
+	}
+
+	if f.nameRegex != nil && !f.nameRegex.MatchString(name) {
+		return false
+	}
+
+	if f.pzType != nil && (pz.Type == nil || *pz.Type != *f.pzType) {
+		return false
+	}
+
+	if f.storageZoneID != nil && (pz.StorageZoneID == nil || *pz.StorageZoneID != *f.storageZoneID) {
+		return false
+	}
+
+	if f.enabled != nil && (pz.Enabled == nil || *pz.Enabled != *f.enabled) {
+		return false
+	}
+
+	return true
+}
+
+func dataSourcePullZonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*client)
+
+	filter, err := pullZoneFilterFromResource(d)
+	if err != nil {
+		return diagsErrFromErr("invalid filter", err)
+	}
+
+	pullZones, err := listPullZones(ctx, clt)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	matches := make([]*bunny.PullZone, 0, len(pullZones))
+	for _, pz := range pullZones {
+		if pz.ID == nil {
+			logger.Warnf("bunny.net api returned pull zone with nil ID, ignoring it: %+v", pz)
+			continue
+		}
+
+		if filter.matches(pz) {
+			matches = append(matches, pz)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return *matches[i].ID < *matches[j].ID
+	})
+
+	res := make([]map[string]interface{}, 0, len(matches))
+	ids := make([]string, 0, len(matches))
+
+	for _, pz := range matches {
+		res = append(res, map[string]interface{}{
+			keyID:            pz.ID,
+			keyName:          pz.Name,
+			keyType:          pz.Type,
+			keyEnabled:       pz.Enabled,
+			keyStorageZoneID: pz.StorageZoneID,
+			keyOriginURL:     pz.OriginURL,
+			keyCnameDomain:   pz.CnameDomain,
+			keyHostnames:     dataSourcePullZoneHostnamesFlatten(pz),
+		})
+		ids = append(ids, strconv.FormatInt(*pz.ID, 10))
+	}
+
+	if err := d.Set(keyPullZones, res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+func TestFakeAPIPullZonesDataSource(t *testing.T) {
+	ctx := context.Background()
+	_, meta := newFakeAPIProvider(t)
+	clt := meta.(*client)
+
+	sz, err := clt.StorageZone.Add(ctx, &bunny.StorageZoneAddOptions{
+		Name:   ptr.ToString(randResourceName()),
+		Region: ptr.ToString("DE"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pzIDs []int64
+	for _, opts := range []*bunny.PullZoneAddOptions{
+		{Name: "web-1", OriginURL: "https://bunny.net"},
+		{Name: "web-2", OriginURL: "https://bunny.net", Type: 1},
+		{Name: "static-1", StorageZoneID: sz.ID},
+	} {
+		pz, err := clt.PullZone.Add(ctx, opts)
+		if err != nil {
+			t.Fatalf("creating pull zone failed: %s", err)
+		}
+
+		pzIDs = append(pzIDs, *pz.ID)
+	}
+
+	testcases := []struct {
+		name    string
+		config  map[string]interface{}
+		wantIDs []int64
+	}{
+		{
+			name:    "no filters",
+			config:  map[string]interface{}{},
+			wantIDs: pzIDs,
+		},
+		{
+			name:    "name prefix",
+			config:  map[string]interface{}{keyNamePrefix: "web-"},
+			wantIDs: pzIDs[0:2],
+		},
+		{
+			name:    "name regex",
+			config:  map[string]interface{}{keyNameRegex: "-1$"},
+			wantIDs: []int64{pzIDs[0], pzIDs[2]},
+		},
+		{
+			name:    "standard type",
+			config:  map[string]interface{}{keyType: 0},
+			wantIDs: []int64{pzIDs[0], pzIDs[2]},
+		},
+		{
+			name:    "volume type",
+			config:  map[string]interface{}{keyType: 1},
+			wantIDs: []int64{pzIDs[1]},
+		},
+		{
+			name:    "storage zone id",
+			config:  map[string]interface{}{keyStorageZoneID: int(*sz.ID)},
+			wantIDs: []int64{pzIDs[2]},
+		},
+		{
+			name:    "disabled",
+			config:  map[string]interface{}{keyEnabled: false},
+			wantIDs: nil,
+		},
+		{
+			name:    "combined filters",
+			config:  map[string]interface{}{keyNamePrefix: "web-", keyEnabled: true, keyType: 1},
+			wantIDs: []int64{pzIDs[1]},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourcePullZones().Schema, tc.config)
+
+			if diags := dataSourcePullZonesRead(ctx, d, meta); diags.HasError() {
+				t.Fatalf("read failed: %+v", diags)
+			}
+
+			pullZones := d.Get(keyPullZones).([]interface{})
+
+			var gotIDs []int64
+			for _, pz := range pullZones {
+				gotIDs = append(gotIDs, int64(pz.(map[string]interface{})[keyID].(int)))
+			}
+
+			if len(gotIDs) != len(tc.wantIDs) {
+				t.Fatalf("got pull zones %v, want %v", gotIDs, tc.wantIDs)
+			}
+
+			for i := range gotIDs {
+				if gotIDs[i] != tc.wantIDs[i] {
+					t.Fatalf("got pull zones %v, want %v", gotIDs, tc.wantIDs)
+				}
+			}
+		})
+	}
+}
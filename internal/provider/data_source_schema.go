@@ -0,0 +1,38 @@
+package provider
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+// dataSourceSchemaFromResourceSchema returns a copy of rs where all
+// attributes, including the ones of nested blocks, are read-only.
+// It allows data sources to expose the same attributes as a resource
+// without duplicating the schema definition.
+func dataSourceSchemaFromResourceSchema(rs map[string]*schema.Schema) map[string]*schema.Schema {
+	res := make(map[string]*schema.Schema, len(rs))
+
+	for k, v := range rs {
+		res[k] = dataSourceSchemaFromResourceSchemaAttr(v)
+	}
+
+	return res
+}
+
+func dataSourceSchemaFromResourceSchemaAttr(rs *schema.Schema) *schema.Schema {
+	res := schema.Schema{
+		Type:        rs.Type,
+		Description: rs.Description,
+		Sensitive:   rs.Sensitive,
+		Computed:    true,
+		Set:         rs.Set,
+	}
+
+	switch elem := rs.Elem.(type) {
+	case *schema.Resource:
+		res.Elem = &schema.Resource{
+			Schema: dataSourceSchemaFromResourceSchema(elem.Schema),
+		}
+	case *schema.Schema:
+		res.Elem = &schema.Schema{Type: elem.Type}
+	}
+
+	return &res
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const keyPullZoneIDs = "pull_zone_ids"
+
+func dataSourceStorageZone() *schema.Resource {
+	s := dataSourceSchemaFromResourceSchema(resourceStorageZone().Schema)
+	// the attributes are not returned by the API
+	delete(s, keyOriginURL)
+	delete(s, keyCustom404FilePath)
+	delete(s, keyRewrite404To200)
+
+	s[keyID] = &schema.Schema{
+		Type:             schema.TypeString,
+		Description:      "The ID of the storage zone.",
+		Optional:         true,
+		Computed:         true,
+		ExactlyOneOf:     []string{keyID, keyName},
+		ValidateDiagFunc: validateIsIDStr,
+	}
+	s[keyName] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The name of the storage zone.",
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{keyID, keyName},
+	}
+	s[keyPullZoneIDs] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: "The IDs of the Pull Zones that are linked to the storage zone.",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+	}
+
+	return &schema.Resource{
+		Description: "Retrieves a storage zone by its ID or name.",
+		ReadContext: dataSourceStorageZoneRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceStorageZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*client)
+
+	var sz *bunny.StorageZone
+
+	if idStr := d.Get(keyID).(string); idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return diagsErrFromErr(fmt.Sprintf("%s is not an integer", keyID), err)
+		}
+
+		sz, err = clt.StorageZone.Get(ctx, id)
+		if err != nil {
+			return diagsErrFromErr("could not retrieve storage zone", err)
+		}
+	} else {
+		var err error
+
+		sz, err = findStorageZoneByName(ctx, clt, d.Get(keyName).(string))
+		if err != nil {
+			return diagsErrFromErr("could not find storage zone", err)
+		}
+	}
+
+	if err := storageZoneToResource(sz, d); err != nil {
+		return diagsErrFromErr("converting api type to data source failed", err)
+	}
+
+	pullZoneIDs := make([]interface{}, 0, len(sz.PullZones))
+	for _, pz := range sz.PullZones {
+		if pz.ID == nil {
+			logger.Warnf("bunny.net api returned storage zone (%s) with a linked pull zone with nil ID", d.Id())
+			continue
+		}
+
+		pullZoneIDs = append(pullZoneIDs, int(*pz.ID))
+	}
+	if err := d.Set(keyPullZoneIDs, pullZoneIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// findStorageZoneByName returns the storage zone with the given name.
+// All pages of the storage zone list API endpoint are requested until it is
+// found.
+func findStorageZoneByName(ctx context.Context, clt *client, name string) (*bunny.StorageZone, error) {
+	for page := int32(1); ; page++ {
+		storageZones, err := clt.StorageZone.List(ctx, &bunny.PaginationOptions{
+			Page:    page,
+			PerPage: 1000,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing storage zones failed: %w", err)
+		}
+
+		for _, sz := range storageZones.Items {
+			if sz.Name == nil || *sz.Name != name {
+				continue
+			}
+
+			if sz.ID == nil {
+				return nil, errors.New("found storage zone with matching name but id is nil")
+			}
+
+			// the list endpoint does not return the linked pull
+			// zones, retrieve the complete storage zone
+			return clt.StorageZone.Get(ctx, *sz.ID)
+		}
+
+		if storageZones.HasMoreItems == nil || !*storageZones.HasMoreItems {
+			return nil, fmt.Errorf("storage zone with name %q: %w", name, errNotFound)
+		}
+	}
+}
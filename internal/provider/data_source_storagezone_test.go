@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+func TestFakeAPIStorageZoneDataSource(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	clt := meta.(*client)
+
+	// create a storage zone that should not be found
+	_, err := clt.StorageZone.Add(ctx, &bunny.StorageZoneAddOptions{
+		Name: ptr.ToString(randResourceName()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	szName := randResourceName()
+	sz, err := clt.StorageZone.Add(ctx, &bunny.StorageZoneAddOptions{
+		Name:   ptr.ToString(szName),
+		Region: ptr.ToString("NY"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pz, err := clt.PullZone.Add(ctx, &bunny.PullZoneAddOptions{
+		Name:          randResourceName(),
+		StorageZoneID: sz.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{
+			name:   "by id",
+			config: map[string]interface{}{keyID: strconv.FormatInt(*sz.ID, 10)},
+		},
+		{
+			name:   "by name",
+			config: map[string]interface{}{keyName: szName},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceStorageZone().Schema, tc.config)
+
+			if diags := dataSourceStorageZoneRead(ctx, d, meta); diags.HasError() {
+				t.Fatalf("read failed: %+v", diags)
+			}
+
+			apiSz, _ := srv.StorageZone(*sz.ID)
+
+			if d.Id() != strconv.FormatInt(*sz.ID, 10) {
+				t.Errorf("got storage zone with id %q, expected %d", d.Id(), *sz.ID)
+			}
+
+			if name := d.Get(keyName).(string); name != szName {
+				t.Errorf("got storage zone with name %q, expected %q", name, szName)
+			}
+
+			if region := d.Get(keyRegion).(string); region != "NY" {
+				t.Errorf("unexpected %s: %q", keyRegion, region)
+			}
+
+			if pw := d.Get(keyPassword).(string); pw != *apiSz.Password {
+				t.Errorf("unexpected %s: %q", keyPassword, pw)
+			}
+
+			if pw := d.Get(keyReadOnlyPassword).(string); pw != *apiSz.ReadOnlyPassword {
+				t.Errorf("unexpected %s: %q", keyReadOnlyPassword, pw)
+			}
+
+			if hostname := d.Get(keyStorageHostname).(string); hostname != "ny.storage.bunnycdn.com" {
+				t.Errorf("unexpected %s: %q", keyStorageHostname, hostname)
+			}
+
+			pzIDs := d.Get(keyPullZoneIDs).(*schema.Set).List()
+			if len(pzIDs) != 1 || int64(pzIDs[0].(int)) != *pz.ID {
+				t.Errorf("expected %s to contain only %d, got: %v", keyPullZoneIDs, *pz.ID, pzIDs)
+			}
+		})
+	}
+}
+
+func TestStorageZoneDataSource_sensitiveAttributes(t *testing.T) {
+	s := dataSourceStorageZone().Schema
+
+	for _, k := range []string{keyPassword, keyReadOnlyPassword} {
+		if !s[k].Sensitive {
+			t.Errorf("%s is not marked as sensitive", k)
+		}
+	}
+}
@@ -1,6 +1,17 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+// errNotFound is returned when an object does not exist.
+var errNotFound = errors.New("not found")
 
 func diagsErrFromErr(summary string, err error) diag.Diagnostics {
 	return diagsFromErr(summary, err, diag.Error)
@@ -17,3 +28,37 @@ func diagsFromErr(summary string, err error, severity diag.Severity) diag.Diagno
 		Detail:   err.Error(),
 	}}
 }
+
+// isNotFoundErr returns true if err is or wraps errNotFound or an error of
+// the bunny API with the HTTP status code 404.
+func isNotFoundErr(err error) bool {
+	if errors.Is(err, errNotFound) {
+		return true
+	}
+
+	var apiErr *bunny.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+
+	var httpErr *bunny.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
+
+// removeFromState removes the resource from the terraform state and returns
+// a warning about it.
+// It is used when a resource was deleted outside of terraform, terraform will
+// then plan to recreate it.
+func removeFromState(d *schema.ResourceData, resourceType string, err error) diag.Diagnostics {
+	diags := diagsWarnFromErr(
+		fmt.Sprintf("%s with id %q was not found, removing it from the state", resourceType, d.Id()),
+		err,
+	)
+	d.SetId("")
+
+	return diags
+}
@@ -5,22 +5,33 @@ import (
 )
 
 var edgeRuleActionTypesStr = map[string]int{
-	"force_ssl":                  bunny.EdgeRuleActionTypeForceSSL,
-	"redirect":                   bunny.EdgeRuleActionTypeRedirect,
-	"origin_url":                 bunny.EdgeRuleActionTypeOriginURL,
-	"override_cache_time":        bunny.EdgeRuleActionTypeOverrideCacheTime,
-	"block_request":              bunny.EdgeRuleActionTypeBlockRequest,
-	"set_response_header":        bunny.EdgeRuleActionTypeSetResponseHeader,
-	"set_request_header":         bunny.EdgeRuleActionTypeSetRequestHeader,
-	"force_download":             bunny.EdgeRuleActionTypeForceDownload,
-	"disable_token_auth":         bunny.EdgeRuleActionTypeDisableTokenAuthentication,
-	"enable_token_auth":          bunny.EdgeRuleActionTypeEnableTokenAuthentication,
-	"override_cache_time_public": bunny.EdgeRuleActionTypeOverrideCacheTimePublic,
-	"ignore_query_string":        bunny.EdgeRuleActionTypeIgnoreQueryString,
-	"disable_optimizer":          bunny.EdgeRuleActionTypeDisableOptimizer,
-	"force_compression":          bunny.EdgeRuleActionTypeForceCompression,
-	"set_status_code":            bunny.EdgeRuleActionTypeSetStatusCode,
-	"bypass_perma_cache":         bunny.EdgeRuleActionTypeBypassPermaCache,
+	"force_ssl":                              bunny.EdgeRuleActionTypeForceSSL,
+	"redirect":                               bunny.EdgeRuleActionTypeRedirect,
+	"origin_url":                             bunny.EdgeRuleActionTypeOriginURL,
+	"override_cache_time":                    bunny.EdgeRuleActionTypeOverrideCacheTime,
+	"block_request":                          bunny.EdgeRuleActionTypeBlockRequest,
+	"set_response_header":                    bunny.EdgeRuleActionTypeSetResponseHeader,
+	"set_request_header":                     bunny.EdgeRuleActionTypeSetRequestHeader,
+	"force_download":                         bunny.EdgeRuleActionTypeForceDownload,
+	"disable_token_auth":                     bunny.EdgeRuleActionTypeDisableTokenAuthentication,
+	"enable_token_auth":                      bunny.EdgeRuleActionTypeEnableTokenAuthentication,
+	"override_cache_time_public":             bunny.EdgeRuleActionTypeOverrideCacheTimePublic,
+	"ignore_query_string":                    bunny.EdgeRuleActionTypeIgnoreQueryString,
+	"disable_optimizer":                      bunny.EdgeRuleActionTypeDisableOptimizer,
+	"force_compression":                      bunny.EdgeRuleActionTypeForceCompression,
+	"set_status_code":                        bunny.EdgeRuleActionTypeSetStatusCode,
+	"bypass_perma_cache":                     bunny.EdgeRuleActionTypeBypassPermaCache,
+	"override_browser_cache_time":            bunny.EdgeRuleActionTypeOverrideBrowserCacheTime,
+	"origin_storage":                         bunny.EdgeRuleActionTypeOriginStorage,
+	"set_network_rate_limit":                 bunny.EdgeRuleActionTypeSetNetworkRateLimit,
+	"set_connection_limit":                   bunny.EdgeRuleActionTypeSetConnectionLimit,
+	"set_requests_per_second_limit":          bunny.EdgeRuleActionTypeSetRequestsPerSecondLimit,
+	"run_edge_script":                        bunny.EdgeRuleActionTypeRunEdgeScript,
+	"origin_magic_containers":                bunny.EdgeRuleActionTypeOriginMagicContainers,
+	"disable_waf":                            bunny.EdgeRuleActionTypeDisableWAF,
+	"retry_origin":                           bunny.EdgeRuleActionTypeRetryOrigin,
+	"override_browser_cache_response_header": bunny.EdgeRuleActionTypeOverrideBrowserCacheResponseHeader,
+	"remove_browser_cache_response_header":   bunny.EdgeRuleActionTypeRemoveBrowserCacheResponseHeader,
 }
 
 var edgeRuleActionTypesInt = reverseStrIntMap(edgeRuleActionTypesStr)
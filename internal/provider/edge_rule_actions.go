@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyEdgeRuleActionURL         = "url"
+	keyEdgeRuleActionStatusCode  = "status_code"
+	keyEdgeRuleActionSeconds     = "seconds"
+	keyEdgeRuleActionHeaderName  = "name"
+	keyEdgeRuleActionHeaderValue = "value"
+)
+
+const edgeRuleRedirectDefaultStatusCode = 301
+
+// edgeRuleAction describes a typed block that configures the parameters of
+// an edge rule action type, as alternative to the action_parameter_1 and
+// action_parameter_2 attributes.
+type edgeRuleAction struct {
+	// actionType is the key of the action type in edgeRuleActionTypesStr,
+	// it is also the name of the block.
+	actionType  string
+	description string
+	attributes  map[string]*schema.Schema
+	// expand returns the action parameters for the attributes of the block.
+	expand func(m structure) (param1, param2 string)
+	// flatten returns the attributes of the block for the action
+	// parameters. If the parameters can not be represented by the block,
+	// nil is returned.
+	flatten func(param1, param2 string) structure
+}
+
+var validateEdgeRuleActionURL = validation.ToDiagFunc(
+	validation.StringMatch(regexp.MustCompile(`^https?://`), "must start with http:// or https://"),
+)
+
+var validateEdgeRuleActionHeaderName = validation.ToDiagFunc(
+	validation.StringMatch(
+		regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$"),
+		"must be a valid HTTP header name",
+	),
+)
+
+var edgeRuleActions = []*edgeRuleAction{
+	{
+		actionType:  "redirect",
+		description: "Redirects the request to another URL.",
+		attributes: map[string]*schema.Schema{
+			keyEdgeRuleActionURL: {
+				Type:             schema.TypeString,
+				Description:      "The URL to that the request is redirected.",
+				Required:         true,
+				ValidateDiagFunc: validateEdgeRuleActionURL,
+			},
+			keyEdgeRuleActionStatusCode: {
+				Type:        schema.TypeInt,
+				Description: "The HTTP status code of the redirect response.\nValid values: 301, 302, 307, 308",
+				Optional:    true,
+				Default:     edgeRuleRedirectDefaultStatusCode,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.IntInSlice([]int{301, 302, 307, 308}),
+				),
+			},
+		},
+		expand: func(m structure) (string, string) {
+			return m.getStr(keyEdgeRuleActionURL), strconv.Itoa(m[keyEdgeRuleActionStatusCode].(int))
+		},
+		flatten: func(param1, param2 string) structure {
+			statusCode, err := strconv.Atoi(param2)
+			if param1 == "" || err != nil {
+				return nil
+			}
+
+			return structure{
+				keyEdgeRuleActionURL:        param1,
+				keyEdgeRuleActionStatusCode: statusCode,
+			}
+		},
+	},
+	{
+		actionType:  "origin_url",
+		description: "Fetches the requested file from another origin.",
+		attributes: map[string]*schema.Schema{
+			keyEdgeRuleActionURL: {
+				Type:             schema.TypeString,
+				Description:      "The URL of the origin.",
+				Required:         true,
+				ValidateDiagFunc: validateEdgeRuleActionURL,
+			},
+		},
+		expand:  edgeRuleActionURLExpand,
+		flatten: edgeRuleActionURLFlatten,
+	},
+	{
+		actionType:  "override_cache_time",
+		description: "Overrides the time for that the response is cached on the edge servers.",
+		attributes: map[string]*schema.Schema{
+			keyEdgeRuleActionSeconds: {
+				Type:             schema.TypeInt,
+				Description:      "The cache time in seconds.",
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+		},
+		expand:  edgeRuleActionSecondsExpand,
+		flatten: edgeRuleActionSecondsFlatten,
+	},
+	{
+		actionType:  "override_cache_time_public",
+		description: "Overrides the time for that the response is cached by browsers.",
+		attributes: map[string]*schema.Schema{
+			keyEdgeRuleActionSeconds: {
+				Type:             schema.TypeInt,
+				Description:      "The cache time in seconds.",
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+		},
+		expand:  edgeRuleActionSecondsExpand,
+		flatten: edgeRuleActionSecondsFlatten,
+	},
+	{
+		actionType:  "set_response_header",
+		description: "Sets a header in the response that is sent to the client.",
+		attributes:  edgeRuleActionHeaderAttributes(),
+		expand:      edgeRuleActionHeaderExpand,
+		flatten:     edgeRuleActionHeaderFlatten,
+	},
+	{
+		actionType:  "set_request_header",
+		description: "Sets a header in the request that is sent to the origin.",
+		attributes:  edgeRuleActionHeaderAttributes(),
+		expand:      edgeRuleActionHeaderExpand,
+		flatten:     edgeRuleActionHeaderFlatten,
+	},
+	{
+		actionType:  "set_status_code",
+		description: "Responds with a status code.",
+		attributes: map[string]*schema.Schema{
+			keyEdgeRuleActionStatusCode: {
+				Type:             schema.TypeInt,
+				Description:      "The HTTP status code of the response.",
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(100, 599)),
+			},
+		},
+		expand: func(m structure) (string, string) {
+			return strconv.Itoa(m[keyEdgeRuleActionStatusCode].(int)), ""
+		},
+		flatten: func(param1, _ string) structure {
+			statusCode, err := strconv.Atoi(param1)
+			if err != nil {
+				return nil
+			}
+
+			return structure{keyEdgeRuleActionStatusCode: statusCode}
+		},
+	},
+}
+
+var edgeRuleActionsByType = func() map[string]*edgeRuleAction {
+	res := make(map[string]*edgeRuleAction, len(edgeRuleActions))
+	for _, action := range edgeRuleActions {
+		res[action.actionType] = action
+	}
+
+	return res
+}()
+
+var edgeRuleActionBlockKeys = func() []string {
+	res := make([]string, 0, len(edgeRuleActions))
+	for _, action := range edgeRuleActions {
+		res = append(res, action.actionType)
+	}
+
+	sort.Strings(res)
+
+	return res
+}()
+
+func edgeRuleActionHeaderAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		keyEdgeRuleActionHeaderName: {
+			Type:             schema.TypeString,
+			Description:      "The name of the header.",
+			Required:         true,
+			ValidateDiagFunc: validateEdgeRuleActionHeaderName,
+		},
+		keyEdgeRuleActionHeaderValue: {
+			Type:        schema.TypeString,
+			Description: "The value of the header.",
+			Required:    true,
+		},
+	}
+}
+
+func edgeRuleActionURLExpand(m structure) (string, string) {
+	return m.getStr(keyEdgeRuleActionURL), ""
+}
+
+func edgeRuleActionURLFlatten(param1, _ string) structure {
+	if param1 == "" {
+		return nil
+	}
+
+	return structure{keyEdgeRuleActionURL: param1}
+}
+
+func edgeRuleActionSecondsExpand(m structure) (string, string) {
+	return strconv.Itoa(m[keyEdgeRuleActionSeconds].(int)), ""
+}
+
+func edgeRuleActionSecondsFlatten(param1, _ string) structure {
+	seconds, err := strconv.Atoi(param1)
+	if err != nil {
+		return nil
+	}
+
+	return structure{keyEdgeRuleActionSeconds: seconds}
+}
+
+func edgeRuleActionHeaderExpand(m structure) (string, string) {
+	return m.getStr(keyEdgeRuleActionHeaderName), m.getStr(keyEdgeRuleActionHeaderValue)
+}
+
+func edgeRuleActionHeaderFlatten(param1, param2 string) structure {
+	if param1 == "" {
+		return nil
+	}
+
+	return structure{
+		keyEdgeRuleActionHeaderName:  param1,
+		keyEdgeRuleActionHeaderValue: param2,
+	}
+}
+
+// edgeRuleActionSchemas returns the schemas of the typed action blocks.
+func edgeRuleActionSchemas() map[string]*schema.Schema {
+	res := make(map[string]*schema.Schema, len(edgeRuleActions))
+
+	for _, action := range edgeRuleActions {
+		res[action.actionType] = &schema.Schema{
+			Type: schema.TypeList,
+			Description: action.description +
+				fmt.Sprintf(" Sets %s to `%s`.", keyEdgeRuleActionType, action.actionType),
+			Optional: true,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: action.attributes},
+		}
+	}
+
+	return res
+}
+
+// edgeRuleActionFromStructure returns the typed action block that is set in
+// the edge rule m and its attributes.
+// If no block is set, nil is returned. If multiple blocks are set, an error
+// is returned.
+func edgeRuleActionFromStructure(m structure) (*edgeRuleAction, structure, error) {
+	var found []*edgeRuleAction
+	var attrs structure
+
+	for _, action := range edgeRuleActions {
+		block := structureFromElem(m[action.actionType].([]interface{}))
+		if block.isEmpty() {
+			continue
+		}
+
+		found = append(found, action)
+		attrs = block
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+		return found[0], attrs, nil
+	default:
+		names := make([]string, 0, len(found))
+		for _, action := range found {
+			names = append(names, action.actionType)
+		}
+
+		return nil, nil, fmt.Errorf("only one of the blocks %s can be specified", strings.Join(names, ", "))
+	}
+}
+
+// validateEdgeRuleAction ensures that the action of the edge rule m is
+// either configured by action_type or by a typed action block. If both are
+// set, they must match. Typed action blocks can not be combined with the
+// action parameters.
+// actionTypeKnown must be false if the value of action_type is only known
+// after other resources were applied.
+func validateEdgeRuleAction(m structure, actionTypeKnown bool) error {
+	action, _, err := edgeRuleActionFromStructure(m)
+	if err != nil {
+		return err
+	}
+
+	actionType := m.getStr(keyEdgeRuleActionType)
+
+	if action == nil {
+		if actionTypeKnown && actionType == "" {
+			return fmt.Errorf("%s or one of the blocks %s must be specified",
+				keyEdgeRuleActionType, strings.Join(edgeRuleActionBlockKeys, ", "),
+			)
+		}
+
+		return nil
+	}
+
+	if actionType != "" && actionType != action.actionType {
+		return fmt.Errorf("%s block can not be specified if %s is %q",
+			action.actionType, keyEdgeRuleActionType, actionType,
+		)
+	}
+
+	for _, key := range []string{keyEdgeRuleActionParameter1, keyEdgeRuleActionParameter2} {
+		if v, exists := m[key]; exists && v.(string) != "" {
+			return fmt.Errorf("%s can not be specified together with the %s block", key, action.actionType)
+		}
+	}
+
+	return nil
+}
+
+// diffSupressEdgeRuleActionTypeFromBlock suppresses the removal of the
+// action_type value from the state when the action type is configured by the
+// matching typed action block.
+func diffSupressEdgeRuleActionTypeFromBlock(k, old, new string, d *schema.ResourceData) bool {
+	if new != "" || edgeRuleActionsByType[old] == nil {
+		return false
+	}
+
+	prefix := strings.TrimSuffix(k, keyEdgeRuleActionType)
+
+	return len(d.Get(prefix+old).([]interface{})) != 0
+}
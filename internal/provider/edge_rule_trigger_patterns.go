@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+var edgeRuleRequestMethods = []string{"CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT", "TRACE"}
+
+var countryStateCodeRegex = regexp.MustCompile(`^([A-Z]{2})-[A-Z0-9]{1,3}$`)
+
+// edgeRuleTriggerPatternValidators contains the validation functions for the
+// pattern matches of the trigger types. Patterns of trigger types without an
+// entry are not validated.
+var edgeRuleTriggerPatternValidators = map[string]func(pattern string) error{
+	"country_code":               validateEdgeRuleCountryCodePattern,
+	"country_state_code":         validateEdgeRuleCountryStateCodePattern,
+	"origin_retry_attempt_count": validateEdgeRuleIntPattern(0, 1000),
+	"random_chance":              validateEdgeRuleIntPattern(0, 100),
+	"remote_ip":                  validateEdgeRuleRemoteIPPattern,
+	"request_method":             validateEdgeRuleRequestMethodPattern,
+	"status_code":                validateEdgeRuleIntPattern(100, 599),
+}
+
+var edgeRuleTriggerPatternValidatedTypes = func() []string {
+	res := make([]string, 0, len(edgeRuleTriggerPatternValidators))
+	for triggerType := range edgeRuleTriggerPatternValidators {
+		res = append(res, triggerType)
+	}
+
+	sort.Strings(res)
+
+	return res
+}()
+
+func validateEdgeRuleCountryCodePattern(pattern string) error {
+	if _, exists := countryCodes[pattern]; exists {
+		return nil
+	}
+
+	if _, exists := countryCodes[strings.ToUpper(pattern)]; exists {
+		return fmt.Errorf("country code %q must be uppercase", pattern)
+	}
+
+	return fmt.Errorf("%q is not an ISO 3166-1 alpha-2 country code", pattern)
+}
+
+func validateEdgeRuleCountryStateCodePattern(pattern string) error {
+	match := countryStateCodeRegex.FindStringSubmatch(pattern)
+	if match == nil {
+		return fmt.Errorf("%q is not an uppercase ISO 3166-2 code in the format <COUNTRY>-<STATE>, e.g. US-CA", pattern)
+	}
+
+	if _, exists := countryCodes[match[1]]; !exists {
+		return fmt.Errorf("%q does not start with an ISO 3166-1 alpha-2 country code", pattern)
+	}
+
+	return nil
+}
+
+func validateEdgeRuleIntPattern(min, max int) func(string) error {
+	return func(pattern string) error {
+		v, err := strconv.Atoi(pattern)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", pattern)
+		}
+
+		if v < min || v > max {
+			return fmt.Errorf("%d is not in the range %d to %d", v, min, max)
+		}
+
+		return nil
+	}
+}
+
+func validateEdgeRuleRemoteIPPattern(pattern string) error {
+	if net.ParseIP(pattern) != nil {
+		return nil
+	}
+
+	if _, _, err := net.ParseCIDR(pattern); err != nil {
+		return fmt.Errorf("%q is not an IP address or CIDR", pattern)
+	}
+
+	return nil
+}
+
+func validateEdgeRuleRequestMethodPattern(pattern string) error {
+	for _, method := range edgeRuleRequestMethods {
+		if pattern == method {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q is not an HTTP request method, valid values: %s",
+		pattern, strings.Join(edgeRuleRequestMethods, ", "),
+	)
+}
+
+// validateEdgeRuleListTriggerPatterns validates the pattern matches of the
+// triggers of the edge rules in the list attribute key of rawConfig, the
+// configuration of a resource or data source.
+func validateEdgeRuleListTriggerPatterns(rawConfig cty.Value, key string) error {
+	if !rawConfig.IsKnown() || rawConfig.IsNull() {
+		return nil
+	}
+
+	rawEdgeRules := rawConfig.GetAttr(key)
+	if !rawEdgeRules.IsKnown() || rawEdgeRules.IsNull() {
+		return nil
+	}
+
+	for i, rawEdgeRule := range rawEdgeRules.AsValueSlice() {
+		prefix := fmt.Sprintf("%s.%d.", key, i)
+
+		if err := validateEdgeRuleTriggerPatterns(rawEdgeRule, prefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateEdgeRuleTriggerPatterns validates the pattern matches of the
+// triggers in rawEdgeRule, the configuration of an edge rule, with the
+// validation function for their trigger type.
+// prefix is prepended to the attribute path in error messages, the path
+// identifies triggers and patterns by their index.
+// Trigger types and patterns that are only known after other resources were
+// applied are not validated.
+func validateEdgeRuleTriggerPatterns(rawEdgeRule cty.Value, prefix string) error {
+	if !rawEdgeRule.IsKnown() || rawEdgeRule.IsNull() {
+		return nil
+	}
+
+	triggers := rawEdgeRule.GetAttr(keyEdgeRuleTriggers)
+	if !triggers.IsKnown() || triggers.IsNull() {
+		return nil
+	}
+
+	for i, trigger := range triggers.AsValueSlice() {
+		if !trigger.IsKnown() || trigger.IsNull() {
+			continue
+		}
+
+		triggerType := trigger.GetAttr(keyEdgeRuleTriggerType)
+		if !triggerType.IsKnown() || triggerType.IsNull() {
+			continue
+		}
+
+		patterns := trigger.GetAttr(keyEdgeRuleTriggerPatternMatches)
+		if !patterns.IsKnown() || patterns.IsNull() {
+			continue
+		}
+
+		for j, pattern := range patterns.AsValueSlice() {
+			if !pattern.IsKnown() || pattern.IsNull() {
+				continue
+			}
+
+			path := fmt.Sprintf("%s%s.%d.%s.%d", prefix, keyEdgeRuleTriggers, i, keyEdgeRuleTriggerPatternMatches, j)
+			if err := validateEdgeRuleTriggerPattern(triggerType.AsString(), pattern.AsString(), path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateEdgeRuleTriggerPattern validates pattern with the validation
+// function for triggerType.
+// path is the attribute path of the pattern, it is part of the error message.
+func validateEdgeRuleTriggerPattern(triggerType, pattern, path string) error {
+	validate := edgeRuleTriggerPatternValidators[triggerType]
+	if validate == nil {
+		return nil
+	}
+
+	if err := validate(pattern); err != nil {
+		return fmt.Errorf("%s: %w (trigger type: %s)", path, err, triggerType)
+	}
+
+	return nil
+}
@@ -3,16 +3,19 @@ package provider
 import bunny "github.com/simplesurance/bunny-go"
 
 var edgeRuleTriggerTypesStr = map[string]int{
-	"url":             bunny.EdgeRuleTriggerTypeURL,
-	"request_header":  bunny.EdgeRuleTriggerTypeRequestHeader,
-	"response_header": bunny.EdgeRuleTriggerTypeResponseHeader,
-	"url_extensions":  bunny.EdgeRuleTriggerTypeURLExtension,
-	"country_code":    bunny.EdgeRuleTriggerTypeCountryCode,
-	"remote_ip":       bunny.EdgeRuleTriggerTypeRemoteIP,
-	"query_string":    bunny.EdgeRuleTriggerTypeURLQueryString,
-	"random_chance":   bunny.EdgeRuleTriggerTypeRandomChance,
-	"status_code":     bunny.EdgeRuleTriggerTypeStatusCode,
-	"request_method":  bunny.EdgeRuleTriggerTypeRequestMethod,
+	"url":                        bunny.EdgeRuleTriggerTypeURL,
+	"request_header":             bunny.EdgeRuleTriggerTypeRequestHeader,
+	"response_header":            bunny.EdgeRuleTriggerTypeResponseHeader,
+	"url_extensions":             bunny.EdgeRuleTriggerTypeURLExtension,
+	"country_code":               bunny.EdgeRuleTriggerTypeCountryCode,
+	"remote_ip":                  bunny.EdgeRuleTriggerTypeRemoteIP,
+	"query_string":               bunny.EdgeRuleTriggerTypeURLQueryString,
+	"random_chance":              bunny.EdgeRuleTriggerTypeRandomChance,
+	"status_code":                bunny.EdgeRuleTriggerTypeStatusCode,
+	"request_method":             bunny.EdgeRuleTriggerTypeRequestMethod,
+	"cookie_value":               bunny.EdgeRuleTriggerTypeCookieValue,
+	"country_state_code":         bunny.EdgeRuleTriggerTypeCountryStateCode,
+	"origin_retry_attempt_count": bunny.EdgeRuleTriggerTypeOriginRetryAttemptCount,
 }
 
 var edgeRuleTriggerTypesInt = reverseStrIntMap(edgeRuleTriggerTypesStr)
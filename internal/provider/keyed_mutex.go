@@ -0,0 +1,56 @@
+package provider
+
+import "sync"
+
+// keyedMutex provides a separate mutual exclusion lock per key.
+// Locking a key only blocks other goroutines that lock the same key.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[int64]*refCountedMutex
+}
+
+type refCountedMutex struct {
+	sync.Mutex
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{
+		locks: map[int64]*refCountedMutex{},
+	}
+}
+
+// Lock locks the mutex for key.
+// If the lock for key is already in use, the calling goroutine blocks until
+// it is available.
+func (m *keyedMutex) Lock(key int64) {
+	m.mu.Lock()
+	l, exists := m.locks[key]
+	if !exists {
+		l = &refCountedMutex{}
+		m.locks[key] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	l.Lock()
+}
+
+// Unlock unlocks the mutex for key.
+// It is a run-time error if the mutex for key is not locked.
+func (m *keyedMutex) Unlock(key int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, exists := m.locks[key]
+	if !exists {
+		panic("keyedMutex: unlock of unlocked key")
+	}
+
+	l.refs--
+	if l.refs == 0 {
+		delete(m.locks, key)
+	}
+
+	l.Unlock()
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutex_DifferentKeysDoNotBlock(t *testing.T) {
+	m := newKeyedMutex()
+
+	m.Lock(1)
+	defer m.Unlock(1)
+
+	locked := make(chan struct{})
+	go func() {
+		m.Lock(2)
+		m.Unlock(2)
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("locking key 2 blocked while key 1 was locked")
+	}
+}
+
+func TestKeyedMutex_SameKeyIsSerialized(t *testing.T) {
+	const goroutines = 50
+
+	m := newKeyedMutex()
+
+	var wg sync.WaitGroup
+	var inCriticalSection int
+	var counter int
+
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+
+			m.Lock(1)
+			defer m.Unlock(1)
+
+			inCriticalSection++
+			if inCriticalSection != 1 {
+				t.Errorf("%d goroutines are in the critical section", inCriticalSection)
+			}
+			counter++
+			inCriticalSection--
+		}()
+	}
+
+	wg.Wait()
+
+	if counter != goroutines {
+		t.Errorf("counter is %d, expected %d", counter, goroutines)
+	}
+
+	if len(m.locks) != 0 {
+		t.Errorf("expected all locks to be released, %d are still allocated", len(m.locks))
+	}
+}
@@ -4,15 +4,26 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	bunny "github.com/simplesurance/bunny-go"
 )
 
 const userAgent = "terraform-provider-bunny"
 const envVarAPIKey = "BUNNY_API_KEY"
+const envVarAPIURL = "BUNNY_API_URL"
 const keyAPIKey = "api_key"
+const keyAPIURL = "api_url"
+const keyMaxRetries = "max_retries"
+const keyRetryMaxWait = "retry_max_wait"
+
+// defaultTimeout is the default duration of the create, read, update and
+// delete timeouts of resources.
+const defaultTimeout = 20 * time.Minute
 
 func init() {
 	// Set descriptions to support markdown syntax, this will be used in document generation
@@ -31,12 +42,42 @@ func New() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc(envVarAPIKey, ""),
 				Description: "The bunny.net API Key.",
 			},
+			keyAPIURL: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(envVarAPIURL, bunny.BaseURL),
+				Description: "The base URL of the bunny.net API.",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.IsURLWithHTTPorHTTPS,
+				),
+			},
+			keyMaxRetries: {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          5,
+				Description:      "The maximum number of times an API request is retried when it failed because of rate limiting (HTTP 429) or a temporary server error (HTTP 5xx). Requests that are not idempotent are only retried on rate limiting. Set to 0 to disable retries.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			keyRetryMaxWait: {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				Description:      "The maximum time in seconds to wait before retrying a failed API request.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"bunny_pullzone":    resourcePullZone(),
-			"bunny_edgerule":    resourceEdgeRule(),
-			"bunny_hostname":    resourceHostname(),
-			"bunny_storagezone": resourceStorageZone(),
+			"bunny_pullzone":           resourcePullZone(),
+			"bunny_edgerule":           resourceEdgeRule(),
+			"bunny_pullzone_edgerules": resourcePullZoneEdgeRules(),
+			"bunny_hostname":           resourceHostname(),
+			"bunny_storagezone":        resourceStorageZone(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"bunny_edgerule_evaluate": dataSourceEdgeRuleEvaluate(),
+			"bunny_pullzone":          dataSourcePullZone(),
+			"bunny_pullzones":         dataSourcePullZones(),
+			"bunny_storagezone":       dataSourceStorageZone(),
 		},
 		ConfigureContextFunc: newProvider,
 	}
@@ -56,11 +97,46 @@ func newProvider(_ context.Context, d *schema.ResourceData) (interface{}, diag.D
 		ua += "-" + Version
 	}
 
+	httpClient := http.Client{
+		Transport: newRetryTransport(
+			http.DefaultTransport,
+			d.Get(keyMaxRetries).(int),
+			time.Duration(d.Get(keyRetryMaxWait).(int))*time.Second,
+		),
+	}
+
 	log.SetFlags(0)
-	return bunny.NewClient(
+	clt := bunny.NewClient(
 		apiKey,
+		bunny.WithBaseURL(d.Get(keyAPIURL).(string)),
+		bunny.WithHTTPClient(&httpClient),
 		bunny.WithUserAgent(ua),
 		bunny.WithHTTPRequestLogger(logger.Debugf),
 		bunny.WithHTTPResponseLogger(logger.Debugf),
-	), nil
+	)
+
+	return &client{
+		Client:        clt,
+		pullZoneCache: newPullZoneCache(clt.PullZone.Get, pullZoneCacheTTL),
+	}, nil
+}
+
+// client is the meta value that is passed to the resource functions.
+type client struct {
+	*bunny.Client
+	// pullZoneCache must be used to retrieve pull zones, pull zones
+	// that were modified must be invalidated in it.
+	pullZoneCache *pullZoneCache
+}
+
+// defaultResourceTimeouts returns timeouts for all CRUD operations of a
+// resource. Declaring them allows users to configure them via a timeouts
+// block.
+func defaultResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultTimeout),
+		Read:   schema.DefaultTimeout(defaultTimeout),
+		Update: schema.DefaultTimeout(defaultTimeout),
+		Delete: schema.DefaultTimeout(defaultTimeout),
+	}
 }
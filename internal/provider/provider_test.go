@@ -1,9 +1,24 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/simplesurance/terraform-provider-bunny/internal/bunnytest"
 )
 
 // resourcePrefix is the prefix that should be used when creating resources at
@@ -25,3 +40,228 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestProvider_ResourcesDeclareTimeouts(t *testing.T) {
+	for name, res := range New().ResourcesMap {
+		if res.Timeouts == nil {
+			t.Errorf("%s: has no timeouts", name)
+			continue
+		}
+
+		if res.Timeouts.Create == nil || res.Timeouts.Read == nil || res.Timeouts.Update == nil || res.Timeouts.Delete == nil {
+			t.Errorf("%s: not all CRUD timeouts are declared: %+v", name, res.Timeouts)
+		}
+	}
+}
+
+// newFakeAPIProvider starts a fake bunny.net API server and returns it
+// together with the meta value of a provider that is configured to send its
+// requests to the server.
+func newFakeAPIProvider(t *testing.T) (*bunnytest.Server, interface{}) {
+	t.Helper()
+
+	srv := bunnytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	p := New()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		keyAPIKey: bunnytest.APIKey,
+		keyAPIURL: srv.URL,
+	}))
+	if diags.HasError() {
+		t.Fatalf("configuring provider failed: %+v", diags)
+	}
+
+	return srv, p.Meta()
+}
+
+// accTest runs the acceptance testcase tc.
+// When the TF_ACC environment variable and the API key environment variable
+// are set, tc runs against the bunny.net API that is configured via the
+// provider environment variables.
+// Otherwise it runs against a bunnytest.Server. This requires a terraform
+// binary, that is looked up via the TF_ACC_TERRAFORM_PATH environment variable
+// or in $PATH. If none is found, the testcase fails when TF_ACC is set and is
+// skipped otherwise.
+func accTest(t *testing.T, tc resource.TestCase) {
+	t.Helper()
+
+	tfAcc := os.Getenv(resource.EnvTfAcc) != ""
+
+	if tfAcc && os.Getenv(envVarAPIKey) != "" {
+		resource.Test(t, tc)
+		return
+	}
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			const msg = "terraform binary not found, set TF_ACC_TERRAFORM_PATH or add terraform to $PATH to run the testcase against the fake API"
+			if tfAcc {
+				t.Fatal(msg)
+			}
+
+			t.Skip(msg)
+		}
+	}
+
+	srv := bunnytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	t.Setenv(envVarAPIKey, bunnytest.APIKey)
+	t.Setenv(envVarAPIURL, srv.URL)
+
+	resource.UnitTest(t, tc)
+}
+
+// assertRemovedFromState fails the test if diags contains an error or the
+// resource was not removed from the state with a warning.
+func assertRemovedFromState(t *testing.T, d *schema.ResourceData, diags diag.Diagnostics) {
+	t.Helper()
+
+	if diags.HasError() {
+		t.Fatalf("read of deleted resource failed: %+v", diags)
+	}
+
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning about the deleted resource, got: %+v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("resource was not removed from state, id is: %q", d.Id())
+	}
+}
+
+// testRawConfig returns the configuration raw as cty.Value, like terraform
+// passes it to the provider.
+// It can be retrieved via GetRawConfig() when it is set as RawConfig in the
+// state that is diffed.
+func testRawConfig(t *testing.T, r *schema.Resource, raw map[string]interface{}) cty.Value {
+	t.Helper()
+
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("marshaling config failed: %s", err)
+	}
+
+	v, err := ctyjson.Unmarshal(buf, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("converting config to cty value failed: %s", err)
+	}
+
+	return v
+}
+
+// testResourceDataCreate returns a ResourceData for creating the resource r
+// with the configuration raw.
+// In contrast to schema.TestResourceDataRaw the raw configuration can be
+// retrieved via GetRawConfig.
+func testResourceDataCreate(t *testing.T, r *schema.Resource, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	state := &terraform.InstanceState{RawConfig: testRawConfig(t, r, raw)}
+
+	diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil, nil, true)
+	if err != nil {
+		t.Fatalf("creating diff failed: %s", err)
+	}
+
+	res, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		t.Fatalf("creating resource data failed: %s", err)
+	}
+
+	return res
+}
+
+// testResourceDataUpdate returns a ResourceData for updating the resource
+// described by d to the configuration raw.
+// In contrast to schema.TestResourceDataRaw the previous state is retained,
+// which allows to test changes of attributes via HasChange and GetChange.
+func testResourceDataUpdate(t *testing.T, r *schema.Resource, d *schema.ResourceData, raw map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+
+	state := d.State()
+	state.RawConfig = testRawConfig(t, r, raw)
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("creating diff failed: %s", err)
+	}
+
+	res, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("creating resource data failed: %s", err)
+	}
+
+	return res
+}
+
+// testPlanCreate plans the creation of the resource of type typeName with the
+// configuration raw via the gRPC provider server, the same way terraform plan
+// does, and returns the errors that were reported.
+// Other than with Resource.Diff the configuration is available via
+// GetRawConfig in CustomizeDiff functions.
+func testPlanCreate(t *testing.T, typeName string, raw map[string]interface{}) error {
+	t.Helper()
+
+	p := New()
+	r, exists := p.ResourcesMap[typeName]
+	if !exists {
+		t.Fatalf("resource type %s does not exist", typeName)
+	}
+
+	ty := r.CoreConfigSchema().ImpliedType()
+
+	config, err := msgpack.Marshal(testRawConfig(t, r, raw), ty)
+	if err != nil {
+		t.Fatalf("encoding config failed: %s", err)
+	}
+
+	priorState, err := msgpack.Marshal(cty.NullVal(ty), ty)
+	if err != nil {
+		t.Fatalf("encoding prior state failed: %s", err)
+	}
+
+	resp, err := schema.NewGRPCProviderServer(p).PlanResourceChange(
+		context.Background(),
+		&tfprotov5.PlanResourceChangeRequest{
+			TypeName:         typeName,
+			PriorState:       &tfprotov5.DynamicValue{MsgPack: priorState},
+			ProposedNewState: &tfprotov5.DynamicValue{MsgPack: config},
+			Config:           &tfprotov5.DynamicValue{MsgPack: config},
+		},
+	)
+	if err != nil {
+		t.Fatalf("planning failed: %s", err)
+	}
+
+	var errs []string
+	for _, d := range resp.Diagnostics {
+		if d.Severity != tfprotov5.DiagnosticSeverityError {
+			continue
+		}
+
+		if d.Detail != "" {
+			errs = append(errs, d.Summary+": "+d.Detail)
+			continue
+		}
+
+		errs = append(errs, d.Summary)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(errs, "; "))
+}
+
+func TestProvider_InvalidAPIURL(t *testing.T) {
+	diags := New().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		keyAPIKey: "key",
+		keyAPIURL: "not-an-url",
+	}))
+	if !diags.HasError() {
+		t.Fatal("validating provider config with an invalid api_url succeeded")
+	}
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const pullZoneCacheTTL = 30 * time.Second
+
+type pullZoneFetchFn func(ctx context.Context, id int64) (*bunny.PullZone, error)
+
+// pullZoneCache caches pull zone documents for a short time.
+//
+// Edge rules and hostnames are not retrievable individually via the API,
+// reading one requires fetching the whole pull zone. The cache allows the
+// child resources of a pull zone to share a single GET request while
+// terraform refreshes them.
+// Concurrent Get calls for a pull zone that is not cached wait for the same
+// request to complete and share its result, including a returned error.
+// After a pull zone was modified via the API, Invalidate must be called.
+//
+// The returned pull zones are shared between callers and must not be
+// modified.
+type pullZoneCache struct {
+	fetch pullZoneFetchFn
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[int64]*pullZoneCacheEntry
+}
+
+type pullZoneCacheEntry struct {
+	// done is closed when the fetch completed and pz and err are set.
+	done chan struct{}
+	pz   *bunny.PullZone
+	err  error
+	// fetchCanceled is true when the context of the caller that did
+	// the fetch was canceled or its deadline expired.
+	fetchCanceled bool
+	// expires is set when the fetch completed successfully, it is
+	// protected by pullZoneCache.mu.
+	expires time.Time
+}
+
+func newPullZoneCache(fetch pullZoneFetchFn, ttl time.Duration) *pullZoneCache {
+	return &pullZoneCache{
+		fetch:   fetch,
+		ttl:     ttl,
+		entries: map[int64]*pullZoneCacheEntry{},
+	}
+}
+
+// Get returns the pull zone with the given id from the cache.
+// If it is not cached or the cached entry expired, the pull zone is fetched
+// from the API.
+func (c *pullZoneCache) Get(ctx context.Context, id int64) (*bunny.PullZone, error) {
+	c.mu.Lock()
+	e, exists := c.entries[id]
+	if exists && !e.expires.IsZero() && time.Now().After(e.expires) {
+		delete(c.entries, id)
+		exists = false
+	}
+
+	if exists {
+		c.mu.Unlock()
+		return c.wait(ctx, id, e)
+	}
+
+	e = &pullZoneCacheEntry{done: make(chan struct{})}
+	c.entries[id] = e
+	c.mu.Unlock()
+
+	e.pz, e.err = c.fetch(ctx, id)
+	e.fetchCanceled = ctx.Err() != nil
+	close(e.done)
+
+	c.mu.Lock()
+	// the entry might have been invalidated while the fetch was in
+	// progress, in that case it must not be stored
+	if c.entries[id] == e {
+		if e.err != nil {
+			delete(c.entries, id)
+		} else {
+			e.expires = time.Now().Add(c.ttl)
+		}
+	}
+	c.mu.Unlock()
+
+	return e.pz, e.err
+}
+
+// wait waits until the fetch of e completed and returns its result.
+func (c *pullZoneCache) wait(ctx context.Context, id int64, e *pullZoneCacheEntry) (*bunny.PullZone, error) {
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if e.fetchCanceled {
+		// the fetch failed because the context of the caller that did
+		// it ended, the error does not apply to us. The failed entry
+		// was removed, Get starts a new fetch that is shared with the
+		// other waiters.
+		return c.Get(ctx, id)
+	}
+
+	return e.pz, e.err
+}
+
+// Invalidate removes the pull zone with the given id from the cache.
+func (c *pullZoneCache) Invalidate(id int64) {
+	c.mu.Lock()
+	delete(c.entries, id)
+	c.mu.Unlock()
+}
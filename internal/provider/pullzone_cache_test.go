@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+// newCountingFetchFn returns a pullZoneFetchFn that returns a pull zone with
+// the requested ID and a counter of its invocations.
+func newCountingFetchFn(delay time.Duration) (pullZoneFetchFn, *int32) {
+	var cnt int32
+
+	return func(ctx context.Context, id int64) (*bunny.PullZone, error) {
+		atomic.AddInt32(&cnt, 1)
+		time.Sleep(delay)
+
+		return &bunny.PullZone{ID: ptr.ToInt64(id)}, nil
+	}, &cnt
+}
+
+func TestPullZoneCache_ConcurrentGetsShareFetch(t *testing.T) {
+	const goroutines = 20
+
+	fetch, cnt := newCountingFetchFn(50 * time.Millisecond)
+	cache := newPullZoneCache(fetch, time.Minute)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+
+			pz, err := cache.Get(context.Background(), 1)
+			if err != nil {
+				t.Errorf("get failed: %s", err)
+				return
+			}
+
+			if pz.ID == nil || *pz.ID != 1 {
+				t.Errorf("got pull zone with unexpected id: %v", pz.ID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(cnt); got != 1 {
+		t.Errorf("pull zone was fetched %d times, expected 1", got)
+	}
+
+	if _, err := cache.Get(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(cnt); got != 2 {
+		t.Errorf("pull zone was fetched %d times, expected 2", got)
+	}
+}
+
+func TestPullZoneCache_Invalidate(t *testing.T) {
+	fetch, cnt := newCountingFetchFn(0)
+	cache := newPullZoneCache(fetch, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Get(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache.Invalidate(1)
+
+	if _, err := cache.Get(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(cnt); got != 2 {
+		t.Errorf("pull zone was fetched %d times, expected 2", got)
+	}
+}
+
+func TestPullZoneCache_EntriesExpire(t *testing.T) {
+	fetch, cnt := newCountingFetchFn(0)
+	cache := newPullZoneCache(fetch, time.Millisecond)
+
+	if _, err := cache.Get(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := cache.Get(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(cnt); got != 2 {
+		t.Errorf("pull zone was fetched %d times, expected 2", got)
+	}
+}
+
+func TestPullZoneCache_ErrorsAreNotCached(t *testing.T) {
+	var cnt int32
+	cache := newPullZoneCache(func(ctx context.Context, id int64) (*bunny.PullZone, error) {
+		if atomic.AddInt32(&cnt, 1) == 1 {
+			return nil, errors.New("fetch failed")
+		}
+
+		return &bunny.PullZone{ID: ptr.ToInt64(id)}, nil
+	}, time.Minute)
+
+	if _, err := cache.Get(context.Background(), 1); err == nil {
+		t.Fatal("first get succeeded, expected an error")
+	}
+
+	if _, err := cache.Get(context.Background(), 1); err != nil {
+		t.Fatalf("second get failed: %s", err)
+	}
+}
+
+func TestPullZoneCache_ConcurrentGetsShareError(t *testing.T) {
+	const goroutines = 20
+
+	var cnt int32
+	cache := newPullZoneCache(func(ctx context.Context, id int64) (*bunny.PullZone, error) {
+		atomic.AddInt32(&cnt, 1)
+		time.Sleep(50 * time.Millisecond)
+
+		return nil, errors.New("fetch failed")
+	}, time.Minute)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+
+			if _, err := cache.Get(context.Background(), 1); err == nil {
+				t.Error("get succeeded, expected an error")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&cnt); got != 1 {
+		t.Errorf("pull zone was fetched %d times, expected 1", got)
+	}
+}
+
+func TestPullZoneCache_WaitersRetryWhenFetchIsCanceled(t *testing.T) {
+	fetch, cnt := newCountingFetchFn(50 * time.Millisecond)
+	cache := newPullZoneCache(func(ctx context.Context, id int64) (*bunny.PullZone, error) {
+		if _, err := fetch(ctx, id); err != nil {
+			return nil, err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return &bunny.PullZone{ID: ptr.ToInt64(id)}, nil
+	}, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fetchErr := make(chan error)
+	go func() {
+		_, err := cache.Get(ctx, 1)
+		fetchErr <- err
+	}()
+
+	// wait until the first Get started fetching, then cancel it while a
+	// second Get waits for the result
+	for atomic.LoadInt32(cnt) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waitResult := make(chan error)
+	go func() {
+		_, err := cache.Get(context.Background(), 1)
+		waitResult <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-fetchErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled get returned %v, expected %v", err, context.Canceled)
+	}
+
+	if err := <-waitResult; err != nil {
+		t.Errorf("waiting get failed: %s", err)
+	}
+
+	if got := atomic.LoadInt32(cnt); got != 2 {
+		t.Errorf("pull zone was fetched %d times, expected 2", got)
+	}
+}
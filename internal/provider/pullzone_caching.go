@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const (
+	keyCacheEnableSmartCache      = "enable_smart_cache"
+	keyCacheUseBackgroundUpdate   = "use_background_update"
+	keyCacheUseStaleWhileOffline  = "use_stale_while_offline"
+	keyCacheUseStaleWhileUpdating = "use_stale_while_updating"
+)
+
+var resourcePullZoneCache = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyCacheEnableSmartCache: {
+			Type:        schema.TypeBool,
+			Description: "If enabled, bunny.net will only cache responses that are considered cacheable by their content type, e.g. images, scripts and stylesheets, and ignore the cache control headers of other responses.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyCacheUseBackgroundUpdate: {
+			Type:        schema.TypeBool,
+			Description: "If enabled, expired cache entries are refreshed in the background while the stale content is served to the client.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyCacheUseStaleWhileOffline: {
+			Type:        schema.TypeBool,
+			Description: "If enabled, bunny.net will serve stale content from the cache if the origin is offline.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyCacheUseStaleWhileUpdating: {
+			Type:        schema.TypeBool,
+			Description: "If enabled, bunny.net will serve stale content from the cache while the cache entry is being updated from the origin.",
+			Optional:    true,
+			Default:     false,
+		},
+	},
+}
+
+func cacheToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
+	m := map[string]interface{}{}
+
+	m[keyCacheEnableSmartCache] = pz.EnableSmartCache
+	m[keyCacheUseBackgroundUpdate] = pz.UseBackgroundUpdate
+	m[keyCacheUseStaleWhileOffline] = pz.UseStaleWhileOffline
+	m[keyCacheUseStaleWhileUpdating] = pz.UseStaleWhileUpdating
+
+	return d.Set(keyCache, []map[string]interface{}{m})
+}
+
+func cacheFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData) {
+	m := structureFromResource(d, keyCache)
+	if len(m) == 0 {
+		return
+	}
+
+	res.EnableSmartCache = m.getBoolPtr(keyCacheEnableSmartCache)
+	res.UseBackgroundUpdate = m.getBoolPtr(keyCacheUseBackgroundUpdate)
+	res.UseStaleWhileOffline = m.getBoolPtr(keyCacheUseStaleWhileOffline)
+	res.UseStaleWhileUpdating = m.getBoolPtr(keyCacheUseStaleWhileUpdating)
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const (
+	keyDDoSProtectionEnabled = "enabled"
+	keyDDoSProtectionType    = "type"
+)
+
+var ddosProtectionTypesStr = map[string]int{
+	"detect_only":       bunny.DDoSProtectionTypeDetectOnly,
+	"active_standard":   bunny.DDoSProtectionTypeActiveStandard,
+	"active_aggressive": bunny.DDoSProtectionTypeActiveAggressive,
+}
+
+var ddosProtectionTypesInt = reverseStrIntMap(ddosProtectionTypesStr)
+
+var ddosProtectionTypeKeys = strIntMapKeysSorted(ddosProtectionTypesStr)
+
+var resourcePullZoneDDoSProtection = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyDDoSProtectionEnabled: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the DDoS protection of bunny.net Shield is enabled.",
+			Optional:    true,
+			Default:     true,
+		},
+		keyDDoSProtectionType: {
+			Type: schema.TypeString,
+			Description: "Determines how bunny.net Shield reacts to detected attacks.\nValid values: " +
+				strings.Join(ddosProtectionTypeKeys, ", "),
+			Optional: true,
+			Default:  "active_standard",
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(ddosProtectionTypeKeys, false),
+			),
+		},
+	},
+}
+
+func ddosProtectionToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
+	m := map[string]interface{}{}
+
+	m[keyDDoSProtectionEnabled] = pz.ShieldDDosProtectionEnabled
+
+	if pz.ShieldDDosProtectionType != nil {
+		protectionType, err := intStrMapGet(ddosProtectionTypesInt, pz.ShieldDDosProtectionType)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", keyDDoSProtection, keyDDoSProtectionType, err)
+		}
+
+		m[keyDDoSProtectionType] = protectionType
+	}
+
+	return d.Set(keyDDoSProtection, []map[string]interface{}{m})
+}
+
+func ddosProtectionFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData) error {
+	m := structureFromResource(d, keyDDoSProtection)
+	if len(m) == 0 {
+		return nil
+	}
+
+	protectionType, err := strIntMapGet(ddosProtectionTypesStr, m.getStr(keyDDoSProtectionType))
+	if err != nil {
+		return fmt.Errorf("%s.%s: %w", keyDDoSProtection, keyDDoSProtectionType, err)
+	}
+
+	res.ShieldDDosProtectionEnabled = m.getBoolPtr(keyDDoSProtectionEnabled)
+	res.ShieldDDosProtectionType = &protectionType
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+// pullZoneTypeVolume is the value of the type attribute for Volume tier pull
+// zones.
+const pullZoneTypeVolume = 1
+
+// geoZoneKeys are the attributes that enable serving data from a geo zone.
+var geoZoneKeys = []string{
+	keyEnableGeoZoneAF,
+	keyEnableGeoZoneAsia,
+	keyEnableGeoZoneEU,
+	keyEnableGeoZoneSA,
+	keyEnableGeoZoneUS,
+}
+
+// validatePullZoneGeoZones rejects geo zone configurations that are not
+// supported by bunny.net:
+//   - at least one geo zone must be enabled,
+//   - Volume tier pull zones can not serve data from the Middle East & Africa
+//     zone,
+//   - countries can not be blocked and redirected at the same time.
+//
+// Attributes with values that are unknown during planning are not validated.
+func validatePullZoneGeoZones(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// isDisabled returns true if the geo zone is known to be disabled
+	isDisabled := func(key string) bool {
+		return d.NewValueKnown(key) && !d.Get(key).(bool)
+	}
+
+	allDisabled := true
+	for _, key := range geoZoneKeys {
+		if !isDisabled(key) {
+			allDisabled = false
+			break
+		}
+	}
+
+	if allDisabled {
+		return fmt.Errorf("at least one of %s must be enabled", strings.Join(geoZoneKeys, ", "))
+	}
+
+	if d.NewValueKnown(keyType) && d.Get(keyType).(int) == pullZoneTypeVolume &&
+		d.NewValueKnown(keyEnableGeoZoneAF) && d.Get(keyEnableGeoZoneAF).(bool) {
+		return fmt.Errorf("%s can not be enabled for Volume tier pull zones (%s = %d)",
+			keyEnableGeoZoneAF, keyType, pullZoneTypeVolume,
+		)
+	}
+
+	if !d.NewValueKnown(keyBudgetRedirectedCountries) || !d.NewValueKnown(keyBlockedCountries) {
+		return nil
+	}
+
+	redirected := strSetAsSlice(d.Get(keyBudgetRedirectedCountries))
+
+	for _, blocked := range strSetAsSlice(d.Get(keyBlockedCountries)) {
+		for _, country := range redirected {
+			if strings.EqualFold(blocked, country) {
+				return fmt.Errorf("country %q can not be in %s and %s",
+					country, keyBlockedCountries, keyBudgetRedirectedCountries,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// geoZonesFromResource sets the geo zone fields in res.
+// The attributes are computed, geo zones that are not configured are sent with
+// the value that was retrieved from the API. When a pull zone is created, they
+// are not sent and bunny.net uses its default for the pull zone type.
+func geoZonesFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData) {
+	for _, e := range []struct {
+		key string
+		val **bool
+	}{
+		{keyEnableGeoZoneAF, &res.EnableGeoZoneAF},
+		{keyEnableGeoZoneAsia, &res.EnableGeoZoneAsia},
+		{keyEnableGeoZoneEU, &res.EnableGeoZoneEU},
+		{keyEnableGeoZoneSA, &res.EnableGeoZoneSA},
+		{keyEnableGeoZoneUS, &res.EnableGeoZoneUS},
+	} {
+		// GetOkExists is required to distinguish between unset
+		// attributes and attributes that are set to false
+		//nolint:staticcheck // see above
+		v, ok := d.GetOkExists(e.key)
+		if !ok {
+			continue
+		}
+
+		enabled := v.(bool)
+		*e.val = &enabled
+	}
+}
@@ -1,7 +1,10 @@
 package provider
 
 import (
+	"math"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	bunny "github.com/simplesurance/bunny-go"
 )
 
@@ -9,6 +12,9 @@ const (
 	keyLimitsConnectionLimitPerIPCount = "connection_limit_per_ip_count"
 	keyLimitsMonthlyBandwidthLimit     = "monthly_bandwidth_limit"
 	keyLimitsRequestLimit              = "request_limit"
+	keyLimitsLimitRatePerSecond        = "limit_rate_per_second"
+	keyLimitsLimitRateAfter            = "limit_rate_after"
+	keyLimitsBurstSize                 = "burst_size"
 )
 
 var resourcePullZoneLimits = &schema.Resource{
@@ -30,6 +36,24 @@ var resourcePullZoneLimits = &schema.Resource{
 			Description: "Limits the allowed bandwidth used in a month, in Bytes. If the limit is reached the zone will be disabled.",
 			Optional:    true,
 		},
+		keyLimitsLimitRatePerSecond: {
+			Type:             schema.TypeFloat,
+			Description:      "Limits the download speed of a single connection, in kB/s. Set to 0 for unlimited.",
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+		},
+		keyLimitsLimitRateAfter: {
+			Type:             schema.TypeFloat,
+			Description:      "The amount of data, in MB, that is transferred with full speed before the download speed limit is applied.",
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+		},
+		keyLimitsBurstSize: {
+			Type:             schema.TypeInt,
+			Description:      "The number of requests a single IP can send above the request limit, before requests are rejected. Set to 0 for no burst.",
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, math.MaxInt32)),
+		},
 	},
 }
 
@@ -39,6 +63,9 @@ func limitsToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
 	m[keyLimitsRequestLimit] = pz.RequestLimit
 	m[keyLimitsMonthlyBandwidthLimit] = pz.MonthlyBandwidthLimit
 	m[keyLimitsConnectionLimitPerIPCount] = pz.ConnectionLimitPerIPCount
+	m[keyLimitsLimitRatePerSecond] = pz.LimitRatePerSecond
+	m[keyLimitsLimitRateAfter] = pz.LimitRateAfter
+	m[keyLimitsBurstSize] = pz.BurstSize
 
 	logger.Infof("limitsToResource: setting to :%+v", m)
 	return d.Set(keyLimits, []map[string]interface{}{m})
@@ -53,4 +80,7 @@ func limitsFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData
 	res.RequestLimit = m.getInt32Ptr(keyLimitsRequestLimit)
 	res.MonthlyBandwidthLimit = m.getInt64Ptr(keyLimitsMonthlyBandwidthLimit)
 	res.ConnectionLimitPerIPCount = m.getInt32Ptr(keyLimitsConnectionLimitPerIPCount)
+	res.LimitRatePerSecond = m.getFloat64Ptr(keyLimitsLimitRatePerSecond)
+	res.LimitRateAfter = m.getFloat64Ptr(keyLimitsLimitRateAfter)
+	res.BurstSize = m.getInt32Ptr(keyLimitsBurstSize)
 }
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+var logForwardingProtocolsStr = map[string]int{
+	"udp":     bunny.LogForwardingProtocolUDP,
+	"tcp":     bunny.LogForwardingProtocolTCP,
+	"tls":     bunny.LogForwardingProtocolTCPEncrypted,
+	"datadog": bunny.LogForwardingProtocolDataDog,
+}
+
+var logForwardingProtocolsInt = reverseStrIntMap(logForwardingProtocolsStr)
+
+var logForwardingProtocolKeys = strIntMapKeysSorted(logForwardingProtocolsStr)
+
+var logFormatsStr = map[string]int{
+	"plain": bunny.LogFormatPlain,
+	"json":  bunny.LogFormatJSON,
+}
+
+var logFormatsInt = reverseStrIntMap(logFormatsStr)
+
+var logFormatKeys = strIntMapKeysSorted(logFormatsStr)
+
+var logAnonymizationTypesStr = map[string]int{
+	"one_digit": bunny.LogAnonymizationTypeOneDigit,
+	"drop":      bunny.LogAnonymizationTypeDrop,
+}
+
+var logAnonymizationTypesInt = reverseStrIntMap(logAnonymizationTypesStr)
+
+var logAnonymizationTypeKeys = strIntMapKeysSorted(logAnonymizationTypesStr)
+
+// validatePullZoneLogForwarding ensures that a destination is configured
+// when log forwarding is enabled.
+func validatePullZoneLogForwarding(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.Get(keyLogForwardingEnabled).(bool) {
+		return nil
+	}
+
+	// unknown values are only known after other resources were applied,
+	// they can not be validated during planning
+	if d.NewValueKnown(keyLogForwardingHostname) && d.Get(keyLogForwardingHostname).(string) == "" {
+		return fmt.Errorf("%s must be set if %s is enabled", keyLogForwardingHostname, keyLogForwardingEnabled)
+	}
+
+	if d.NewValueKnown(keyLogForwardingPort) && d.Get(keyLogForwardingPort).(int) <= 0 {
+		return fmt.Errorf("%s must be set if %s is enabled", keyLogForwardingPort, keyLogForwardingEnabled)
+	}
+
+	return nil
+}
+
+func loggingToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
+	for _, e := range []struct {
+		key string
+		m   map[int]string
+		val *int
+	}{
+		{keyLogAnonymizationType, logAnonymizationTypesInt, pz.LogAnonymizationType},
+		{keyLogFormat, logFormatsInt, pz.LogFormat},
+		{keyLogForwardingFormat, logFormatsInt, pz.LogForwardingFormat},
+		{keyLogForwardingProtocol, logForwardingProtocolsInt, pz.LogForwardingProtocol},
+	} {
+		if e.val == nil {
+			continue
+		}
+
+		s, err := intStrMapGet(e.m, e.val)
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.key, err)
+		}
+
+		if err := d.Set(e.key, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func loggingFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData) error {
+	anonymizationType, err := strIntMapGet(logAnonymizationTypesStr, d.Get(keyLogAnonymizationType).(string))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyLogAnonymizationType, err)
+	}
+
+	logFormat, err := strIntMapGet(logFormatsStr, d.Get(keyLogFormat).(string))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyLogFormat, err)
+	}
+
+	forwardingFormat, err := strIntMapGet(logFormatsStr, d.Get(keyLogForwardingFormat).(string))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyLogForwardingFormat, err)
+	}
+
+	forwardingProtocol, err := strIntMapGet(logForwardingProtocolsStr, d.Get(keyLogForwardingProtocol).(string))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyLogForwardingProtocol, err)
+	}
+
+	res.LogAnonymizationType = &anonymizationType
+	res.LogFormat = &logFormat
+	res.LogForwardingFormat = &forwardingFormat
+	res.LogForwardingProtocol = &forwardingProtocol
+
+	return nil
+}
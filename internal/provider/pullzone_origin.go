@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const (
+	keyOriginType       = "type"
+	keyOriginHostHeader = "host_header"
+	keyOriginScriptID   = "script_id"
+)
+
+const (
+	originTypeURL           = "url"
+	originTypeDNSAccelerate = "dns_accelerate"
+	originTypeStorageZone   = "storage_zone"
+	originTypeScript        = "script"
+)
+
+var originTypesStr = map[string]int{
+	originTypeURL:           int(bunny.OriginTypeURL),
+	originTypeDNSAccelerate: int(bunny.OriginTypeDNSAccelerate),
+	originTypeStorageZone:   int(bunny.OriginTypeStorageZone),
+	originTypeScript:        int(bunny.OriginTypeEdgeScript),
+}
+
+var originTypesInt = reverseStrIntMap(originTypesStr)
+
+var originTypeKeys = strIntMapKeysSorted(originTypesStr)
+
+var resourcePullZoneOrigin = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyOriginType: {
+			Type: schema.TypeString,
+			Description: "The type of the origin from that the files are fetched.\n" +
+				fmt.Sprintf("`%s` and `%s` require %s, `%s` requires %s, `%s` requires %s.\n",
+					originTypeURL, originTypeDNSAccelerate, keyOriginURL,
+					originTypeStorageZone, keyStorageZoneID,
+					originTypeScript, keyOriginScriptID,
+				) +
+				"Valid values: " + strings.Join(originTypeKeys, ", "),
+			Required: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(originTypeKeys, false),
+			),
+		},
+		keyOriginHostHeader: {
+			Type:        schema.TypeString,
+			Description: "The value of the Host header that is sent in requests to the origin. If empty, the hostname of the origin URL is used.",
+			Optional:    true,
+		},
+		keyOriginScriptID: {
+			Type:        schema.TypeInt,
+			Description: fmt.Sprintf("The ID of the edge script that is used as origin, if %s is %s.", keyOriginType, originTypeScript),
+			Optional:    true,
+		},
+	},
+}
+
+// validatePullZoneOrigin ensures that the attributes that are required by the
+// origin type are set and the ones of other origin types are not.
+// If no origin block is defined, exactly one of origin_url or storage_zone_id
+// must be set.
+func validatePullZoneOrigin(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// attributes with unknown values are set to a value that is only
+	// known after other resources were applied
+	isSet := func(key string) bool {
+		if !d.NewValueKnown(key) {
+			return true
+		}
+
+		_, ok := d.GetOk(key)
+		return ok
+	}
+
+	originURLSet := isSet(keyOriginURL)
+	storageZoneIDSet := isSet(keyStorageZoneID)
+
+	if !originConfigured(d.GetRawConfig()) {
+		if originURLSet && storageZoneIDSet {
+			return fmt.Errorf("only one of `%s,%s` can be specified", keyOriginURL, keyStorageZoneID)
+		}
+
+		if !originURLSet && !storageZoneIDSet {
+			return fmt.Errorf("one of `%s,%s` must be specified", keyOriginURL, keyStorageZoneID)
+		}
+
+		return nil
+	}
+
+	m := structureFromResource(d, keyOrigin)
+	originType := m.getStr(keyOriginType)
+	scriptIDSet := isSet(keyOrigin + ".0." + keyOriginScriptID)
+
+	attrs := []struct {
+		key      string
+		set      bool
+		required bool
+	}{
+		{keyOriginURL, originURLSet, originType == originTypeURL || originType == originTypeDNSAccelerate},
+		{keyStorageZoneID, storageZoneIDSet, originType == originTypeStorageZone},
+		{keyOrigin + "." + keyOriginScriptID, scriptIDSet, originType == originTypeScript},
+	}
+
+	for _, attr := range attrs {
+		if attr.required && !attr.set {
+			return fmt.Errorf("%s must be set if the origin type is %q", attr.key, originType)
+		}
+
+		if !attr.required && attr.set {
+			return fmt.Errorf("%s can not be set if the origin type is %q", attr.key, originType)
+		}
+	}
+
+	return nil
+}
+
+// computePullZoneOrigin marks the origin block as unknown, when it is not
+// configured and the attributes from that the origin type is derived change.
+var computePullZoneOrigin = customdiff.ComputedIf(keyOrigin, func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+	return !originConfigured(d.GetRawConfig()) && d.HasChanges(keyOriginURL, keyStorageZoneID)
+})
+
+// originConfigured returns true if the origin block is defined in the
+// configuration rawConfig.
+// The block is computed, when it is not configured it contains the origin that
+// was retrieved from the API instead.
+func originConfigured(rawConfig cty.Value) bool {
+	if !rawConfig.IsKnown() || rawConfig.IsNull() {
+		return false
+	}
+
+	origin := rawConfig.GetAttr(keyOrigin)
+
+	return !origin.IsKnown() || (!origin.IsNull() && origin.LengthInt() > 0)
+}
+
+// originToResource sets the origin block in d.
+func originToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
+	origin, err := originFlatten(pz)
+	if err != nil {
+		return err
+	}
+
+	return d.Set(keyOrigin, origin)
+}
+
+func originFlatten(pz *bunny.PullZone) ([]map[string]interface{}, error) {
+	m := map[string]interface{}{}
+
+	if pz.OriginType != nil {
+		originType := int(*pz.OriginType)
+
+		s, err := intStrMapGet(originTypesInt, &originType)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", keyOrigin, keyOriginType, err)
+		}
+
+		m[keyOriginType] = s
+	}
+
+	m[keyOriginHostHeader] = pz.OriginHostHeader
+	m[keyOriginScriptID] = pz.EdgeScriptID
+
+	return []map[string]interface{}{m}, nil
+}
+
+// originTypeFromResource returns the API value of the origin type.
+// If no origin block is configured, the type is derived from the
+// storage_zone_id attribute.
+func originTypeFromResource(d *schema.ResourceData) (int32, error) {
+	if !originConfigured(d.GetRawConfig()) {
+		if _, ok := d.GetOk(keyStorageZoneID); ok {
+			return bunny.OriginTypeStorageZone, nil
+		}
+
+		return bunny.OriginTypeURL, nil
+	}
+
+	m := structureFromResource(d, keyOrigin)
+	originType, err := strIntMapGet(originTypesStr, m.getStr(keyOriginType))
+	if err != nil {
+		return -1, fmt.Errorf("%s.%s: %w", keyOrigin, keyOriginType, err)
+	}
+
+	return int32(originType), nil
+}
+
+func originFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData) error {
+	// The origin type is only sent if it is explicitly configured or the
+	// origin changed, to not modify the origin of pull zones that were
+	// created with the API default.
+	configured := originConfigured(d.GetRawConfig())
+	if configured || d.HasChanges(keyOriginURL, keyStorageZoneID) {
+		originType, err := originTypeFromResource(d)
+		if err != nil {
+			return err
+		}
+
+		res.OriginType = &originType
+	}
+
+	if d.HasChange(keyStorageZoneID) {
+		res.StorageZoneID = getInt64Ptr(d, keyStorageZoneID)
+	}
+
+	if !configured {
+		return nil
+	}
+
+	m := structureFromResource(d, keyOrigin)
+	res.OriginHostHeader = m.getStrPtr(keyOriginHostHeader)
+
+	if m.getStr(keyOriginType) == originTypeScript {
+		res.EdgeScriptID = m.getInt64Ptr(keyOriginScriptID)
+	}
+
+	return nil
+}
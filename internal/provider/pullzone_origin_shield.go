@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const (
+	keyOriginShieldEnabled                = "enabled"
+	keyOriginShieldZoneCode               = "zone_code"
+	keyOriginShieldEnableConcurrencyLimit = "enable_concurrency_limit"
+	keyOriginShieldMaxConcurrentRequests  = "max_concurrent_requests"
+	keyOriginShieldMaxQueuedRequests      = "max_queued_requests"
+	keyOriginShieldQueueMaxWaitTime       = "queue_max_wait_time"
+)
+
+var resourcePullZoneOriginShield = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyOriginShieldEnabled: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the origin shield should be enabled.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyOriginShieldZoneCode: {
+			Type:        schema.TypeString,
+			Description: "Determines the zone code where the origin shield should be set up.",
+			Optional:    true,
+			Default:     "FR",
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice([]string{"FR", "IL"}, false),
+			),
+		},
+		keyOriginShieldEnableConcurrencyLimit: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the number of concurrent requests from the origin shield to the origin is limited.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyOriginShieldMaxConcurrentRequests: {
+			Type:             schema.TypeInt,
+			Description:      "The maximum number of concurrent requests that the origin shield sends to the origin.",
+			Optional:         true,
+			Default:          200,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, math.MaxInt32)),
+		},
+		keyOriginShieldMaxQueuedRequests: {
+			Type:             schema.TypeInt,
+			Description:      "The maximum number of requests that are queued when the concurrency limit is reached. Requests that exceed the limit are rejected.",
+			Optional:         true,
+			Default:          5000,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, math.MaxInt32)),
+		},
+		keyOriginShieldQueueMaxWaitTime: {
+			Type:             schema.TypeInt,
+			Description:      "The maximum number of seconds a request waits in the queue before it is rejected.",
+			Optional:         true,
+			Default:          30,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, math.MaxInt32)),
+		},
+	},
+}
+
+func originShieldToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
+	m := map[string]interface{}{}
+
+	m[keyOriginShieldEnabled] = pz.EnableOriginShield
+	m[keyOriginShieldZoneCode] = pz.OriginShieldZoneCode
+	m[keyOriginShieldEnableConcurrencyLimit] = pz.OriginShieldEnableConcurrencyLimit
+	m[keyOriginShieldMaxConcurrentRequests] = pz.OriginShieldMaxConcurrentRequests
+	m[keyOriginShieldMaxQueuedRequests] = pz.OriginShieldMaxQueuedRequests
+	m[keyOriginShieldQueueMaxWaitTime] = pz.OriginShieldQueueMaxWaitTime
+
+	return d.Set(keyOriginShield, []map[string]interface{}{m})
+}
+
+func originShieldFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData) {
+	m := structureFromResource(d, keyOriginShield)
+	if len(m) == 0 {
+		return
+	}
+
+	res.EnableOriginShield = m.getBoolPtr(keyOriginShieldEnabled)
+	res.OriginShieldZoneCode = m.getStrPtr(keyOriginShieldZoneCode)
+	res.OriginShieldEnableConcurrencyLimit = m.getBoolPtr(keyOriginShieldEnableConcurrencyLimit)
+	res.OriginShieldMaxConcurrentRequests = m.getInt32Ptr(keyOriginShieldMaxConcurrentRequests)
+	res.OriginShieldMaxQueuedRequests = m.getInt32Ptr(keyOriginShieldMaxQueuedRequests)
+	res.OriginShieldQueueMaxWaitTime = m.getInt32Ptr(keyOriginShieldQueueMaxWaitTime)
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const (
+	keyEnableAvifVary            = "enable_avif_vary"
+	keyEnableCookieVary          = "enable_cookie_vary"
+	keyEnableCountryCodeVary     = "enable_country_code_vary"
+	keyEnableHostnameVary        = "enable_hostname_vary"
+	keyEnableMobileVary          = "enable_mobile_vary"
+	keyEnableWebPVary            = "enable_webp_vary"
+	keyCookieVaryParameters      = "cookie_vary_parameters"
+	keyQueryStringVaryParameters = "query_string_vary_parameters"
+)
+
+var resourcePullZoneVaryCache = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyEnableAvifVary: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the AVIF Vary feature should be enabled.",
+			Default:     false,
+			Optional:    true,
+		},
+		keyEnableCookieVary: {
+			Type:        schema.TypeBool,
+			Description: fmt.Sprintf("Determines if the cache should vary by the cookies listed in %s.", keyCookieVaryParameters),
+			Default:     false,
+			Optional:    true,
+		},
+		keyEnableCountryCodeVary: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the Country Code Vary feature should be enabled.",
+			Default:     false,
+			Optional:    true,
+		},
+		keyEnableHostnameVary: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the Hostname Vary feature should be enabled.",
+			Default:     false,
+			Optional:    true,
+		},
+		keyEnableMobileVary: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the Mobile Vary feature is enabled.",
+			Default:     false,
+			Optional:    true,
+		},
+		keyEnableWebPVary: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the WebP Vary feature should be enabled.",
+			Default:     false,
+			Optional:    true,
+		},
+		keyCookieVaryParameters: {
+			Type:        schema.TypeSet,
+			Description: fmt.Sprintf("The names of the cookies that split the cache. Requires %s to be enabled.", keyEnableCookieVary),
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+		},
+		keyQueryStringVaryParameters: {
+			Type: schema.TypeSet,
+			Description: fmt.Sprintf(
+				"The names of the query parameters that split the cache. If empty, the cache varies by all query parameters. Requires %s to be disabled.",
+				keyIgnoreQueryStrings,
+			),
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Optional: true,
+		},
+	},
+}
+
+// validatePullZoneVaryCache ensures that vary parameters are only set when
+// the cache varies by the corresponding request attribute.
+func validatePullZoneVaryCache(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	m := structureFromResource(d, keyVaryCache)
+	if m.isEmpty() {
+		return nil
+	}
+
+	if len(strSetAsSlice(m[keyCookieVaryParameters])) > 0 && !m[keyEnableCookieVary].(bool) {
+		return fmt.Errorf("%s.%s can only be set if %s is enabled",
+			keyVaryCache, keyCookieVaryParameters, keyEnableCookieVary,
+		)
+	}
+
+	if len(strSetAsSlice(m[keyQueryStringVaryParameters])) > 0 && d.Get(keyIgnoreQueryStrings).(bool) {
+		return fmt.Errorf("%s.%s can only be set if %s is disabled",
+			keyVaryCache, keyQueryStringVaryParameters, keyIgnoreQueryStrings,
+		)
+	}
+
+	return nil
+}
+
+func varyCacheToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
+	m := map[string]interface{}{}
+
+	m[keyEnableAvifVary] = pz.EnableAvifVary
+	m[keyEnableCookieVary] = pz.EnableCookieVary
+	m[keyEnableCountryCodeVary] = pz.EnableCountryCodeVary
+	m[keyEnableHostnameVary] = pz.EnableHostnameVary
+	m[keyEnableMobileVary] = pz.EnableMobileVary
+	m[keyEnableWebPVary] = pz.EnableWebPVary
+	m[keyCookieVaryParameters] = pz.CookieVaryParameters
+	m[keyQueryStringVaryParameters] = pz.QueryStringVaryParameters
+
+	return d.Set(keyVaryCache, []map[string]interface{}{m})
+}
+
+func varyCacheFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData) {
+	m := structureFromResource(d, keyVaryCache)
+	if len(m) == 0 {
+		return
+	}
+
+	res.EnableAvifVary = m.getBoolPtr(keyEnableAvifVary)
+	res.EnableCookieVary = m.getBoolPtr(keyEnableCookieVary)
+	res.EnableCountryCodeVary = m.getBoolPtr(keyEnableCountryCodeVary)
+	res.EnableHostnameVary = m.getBoolPtr(keyEnableHostnameVary)
+	res.EnableMobileVary = m.getBoolPtr(keyEnableMobileVary)
+	res.EnableWebPVary = m.getBoolPtr(keyEnableWebPVary)
+	res.CookieVaryParameters = strSetAsSlice(m[keyCookieVaryParameters])
+	res.QueryStringVaryParameters = strSetAsSlice(m[keyQueryStringVaryParameters])
+}
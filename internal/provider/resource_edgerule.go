@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"sync"
 
+	ptr "github.com/AlekSi/pointer"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,14 +15,6 @@ import (
 	bunny "github.com/simplesurance/bunny-go"
 )
 
-// edgeRuleUpdateMu serializes create/update operations of edge rules. The edge
-// rule api endpoint is currently not concurrency safe. If multiple
-// create/update operations are done In Parallel, it might happen that changes
-// get lost.
-// Serializing crete/update operation reduces the chance that concurrency
-// issues occur.
-var edgeRuleUpdateMu sync.Mutex
-
 const (
 	keyEdgeRuleActionParameter1           = "action_parameter_1"
 	keyEdgeRuleActionParameter2           = "action_parameter_2"
@@ -39,7 +31,7 @@ const (
 )
 
 func resourceEdgeRule() *schema.Resource {
-	return &schema.Resource{
+	res := &schema.Resource{
 		CreateContext: resourceEdgeRuleCreate,
 		ReadContext:   resourceEdgeRuleRead,
 		DeleteContext: resourceEdgeRuleDelete,
@@ -47,6 +39,14 @@ func resourceEdgeRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceEdgeRuleImport,
 		},
+		Timeouts: defaultResourceTimeouts(),
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if err := validateEdgeRuleAction(edgeRuleToStructure(d), d.NewValueKnown(keyEdgeRuleActionType)); err != nil {
+				return err
+			}
+
+			return validateEdgeRuleTriggerPatterns(d.GetRawConfig(), "")
+		},
 
 		Schema: map[string]*schema.Schema{
 			keyEdgeRulePullZoneID: {
@@ -57,22 +57,29 @@ func resourceEdgeRule() *schema.Resource {
 			},
 			keyEdgeRuleActionType: {
 				Type: schema.TypeString,
-				Description: "The action type of the Edge Rule.\nValid values: " +
+				Description: "The action type of the Edge Rule. " +
+					"Can be omitted if the action is configured by one of the blocks " +
+					strings.Join(edgeRuleActionBlockKeys, ", ") + ".\nValid values: " +
 					strings.Join(edgeRuleActionTypeKeys, ", "),
 				ValidateDiagFunc: validation.ToDiagFunc(
 					validation.StringInSlice(edgeRuleActionTypeKeys, false),
 				),
-				Required: true,
+				Optional:         true,
+				DiffSuppressFunc: diffSupressEdgeRuleActionTypeFromBlock,
 			},
 			keyEdgeRuleActionParameter1: {
-				Type:        schema.TypeString,
-				Description: "The Action parameter 1. The value depends on other parameters of the edge rule.",
-				Optional:    true,
+				Type: schema.TypeString,
+				Description: "The Action parameter 1. The value depends on other parameters of the edge rule. " +
+					"For the action types " + strings.Join(edgeRuleActionBlockKeys, ", ") +
+					" the block with the name of the action type should be used instead, it validates the parameters.",
+				Optional: true,
 			},
 			keyEdgeRuleActionParameter2: {
-				Type:        schema.TypeString,
-				Description: "The Action parameter 2. The value depends on other parameters of the edge rule.",
-				Optional:    true,
+				Type: schema.TypeString,
+				Description: "The Action parameter 2. The value depends on other parameters of the edge rule. " +
+					"For the action types " + strings.Join(edgeRuleActionBlockKeys, ", ") +
+					" the block with the name of the action type should be used instead, it validates the parameters.",
+				Optional: true,
 			},
 			keyEdgeRuleTriggers: {
 				Type:     schema.TypeSet,
@@ -90,10 +97,13 @@ func resourceEdgeRule() *schema.Resource {
 							),
 						},
 						keyEdgeRuleTriggerPatternMatches: {
-							Type:        schema.TypeSet,
-							Description: "The list of pattern matches that will trigger the edge rule.",
-							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
+							Type: schema.TypeSet,
+							Description: "The list of pattern matches that will trigger the edge rule. " +
+								"The patterns of the trigger types " + strings.Join(edgeRuleTriggerPatternValidatedTypes, ", ") +
+								" are validated, e.g. country codes must be uppercase ISO 3166-1 alpha-2 codes and " +
+								"remote IPs must be IP addresses or CIDRs.",
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
 						keyEdgeRuleTriggerPatternMatchingType: {
 							Type: schema.TypeString,
@@ -134,11 +144,17 @@ func resourceEdgeRule() *schema.Resource {
 			},
 		},
 	}
+
+	for k, v := range edgeRuleActionSchemas() {
+		res.Schema[k] = v
+	}
+
+	return res
 }
 
 // findEdgeRuleGUID retrieves the Pull Zone from the bunny API and returns the guid of the first found edge rule that matches the Description.
-func findEdgeRuleGUID(ctx context.Context, clt *bunny.Client, pullZoneID int64, description string) (string, error) {
-	pz, err := clt.PullZone.Get(ctx, pullZoneID)
+func findEdgeRuleGUID(ctx context.Context, clt *client, pullZoneID int64, description string) (string, error) {
+	pz, err := clt.pullZoneCache.Get(ctx, pullZoneID)
 	if err != nil {
 		return "", fmt.Errorf("retrieving pull zone failed: %w", err)
 	}
@@ -157,7 +173,7 @@ func findEdgeRuleGUID(ctx context.Context, clt *bunny.Client, pullZoneID int64,
 }
 
 func resourceEdgeRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	// The bunny API endpoint does not return the ID of a newly created
 	// Edge Rule.  To be able to identify the created edge rule uniquely
@@ -178,9 +194,10 @@ func resourceEdgeRuleCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
 
-	edgeRuleUpdateMu.Lock()
-	defer edgeRuleUpdateMu.Unlock()
+	pullZoneMu.Lock(pullZoneID)
+	defer pullZoneMu.Unlock(pullZoneID)
 	err = clt.PullZone.AddOrUpdateEdgeRule(ctx, pullZoneID, opts)
+	clt.pullZoneCache.Invalidate(pullZoneID)
 	if err != nil {
 		return diagsErrFromErr("creating edge rule failed", err)
 	}
@@ -197,7 +214,7 @@ func resourceEdgeRuleCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceEdgeRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	opts, err := edgeRuleFromResource(d)
 	if err != nil {
@@ -206,9 +223,10 @@ func resourceEdgeRuleUpdate(ctx context.Context, d *schema.ResourceData, meta in
 
 	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
 
-	edgeRuleUpdateMu.Lock()
-	defer edgeRuleUpdateMu.Unlock()
+	pullZoneMu.Lock(pullZoneID)
+	defer pullZoneMu.Unlock(pullZoneID)
 	err = clt.PullZone.AddOrUpdateEdgeRule(ctx, pullZoneID, opts)
+	clt.pullZoneCache.Invalidate(pullZoneID)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("updating edge rule failed: %w", err))
 	}
@@ -252,8 +270,7 @@ func edgeRuleTriggerTypeToInt(triggerType string) (int, error) {
 	return -1, fmt.Errorf("unsupported trigger type type: %q", triggerType)
 }
 
-func edgeRuleTriggersFromResource(d *schema.ResourceData) ([]*bunny.EdgeRuleTrigger, error) {
-	triggerSet := d.Get(keyEdgeRuleTriggers).(*schema.Set)
+func edgeRuleTriggersExpand(triggerSet *schema.Set) ([]*bunny.EdgeRuleTrigger, error) {
 	if triggerSet.Len() == 0 {
 		return nil, nil
 	}
@@ -299,46 +316,90 @@ func edgeRuleFromResource(d *schema.ResourceData) (*bunny.AddOrUpdateEdgeRuleOpt
 		guid = &id
 	}
 
-	actionType, err := strIntMapGet(
-		edgeRuleActionTypesStr,
-		d.Get(keyEdgeRuleActionType).(string),
-	)
+	return edgeRuleExpand(guid, edgeRuleToStructure(d))
+}
+
+// edgeRuleToStructure returns the attributes of the bunny_edgerule resource
+// d, except the pull zone ID, as structure.
+func edgeRuleToStructure(d resourceDataGetter) structure {
+	m := structure{}
+	for _, k := range append([]string{
+		keyEdgeRuleActionType,
+		keyEdgeRuleActionParameter1,
+		keyEdgeRuleActionParameter2,
+		keyEdgeRuleTriggers,
+		keyEdgeRuleTriggerMatchingType,
+		keyEdgeRuleDescription,
+		keyEdgeRuleEnabled,
+	}, edgeRuleActionBlockKeys...) {
+		m[k] = d.Get(k)
+	}
+
+	return m
+}
+
+// edgeRuleExpand converts the attributes of an edge rule in m to an
+// AddOrUpdateEdgeRuleOptions API type.
+// If a typed action block is set, the action type and parameters are
+// derived from it, otherwise from action_type and the action_parameter_1
+// and action_parameter_2 attributes.
+func edgeRuleExpand(guid *string, m structure) (*bunny.AddOrUpdateEdgeRuleOptions, error) {
+	action, actionAttrs, err := edgeRuleActionFromStructure(m)
+	if err != nil {
+		return nil, err
+	}
+
+	actionTypeStr := m.getStr(keyEdgeRuleActionType)
+	var actionParam1, actionParam2 *string
+
+	if action != nil {
+		actionTypeStr = action.actionType
+
+		p1, p2 := action.expand(actionAttrs)
+		actionParam1, actionParam2 = &p1, &p2
+	} else if _, exists := m[keyEdgeRuleActionParameter1]; exists {
+		actionParam1 = m.getStrPtr(keyEdgeRuleActionParameter1)
+		actionParam2 = m.getStrPtr(keyEdgeRuleActionParameter2)
+	}
+
+	actionType, err := strIntMapGet(edgeRuleActionTypesStr, actionTypeStr)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", keyEdgeRuleActionType, err)
 	}
 
-	matchingType, err := strIntMapGet(
-		edgeRuleMatchingTypesStr,
-		d.Get(keyEdgeRuleTriggerMatchingType).(string),
-	)
+	matchingType, err := strIntMapGet(edgeRuleMatchingTypesStr, m.getStr(keyEdgeRuleTriggerMatchingType))
 	if err != nil {
 		return nil, err
 	}
 
-	triggers, err := edgeRuleTriggersFromResource(d)
+	triggers, err := edgeRuleTriggersExpand(m[keyEdgeRuleTriggers].(*schema.Set))
 	if err != nil {
 		return nil, fmt.Errorf("converting edge rule triggers failed: %w", err)
 	}
 
 	return &bunny.AddOrUpdateEdgeRuleOptions{
 		GUID:                guid,
-		Enabled:             getBoolPtr(d, keyEnabled),
+		Enabled:             m.getBoolPtr(keyEdgeRuleEnabled),
 		ActionType:          &actionType,
-		ActionParameter1:    getStrPtr(d, keyEdgeRuleActionParameter1),
-		ActionParameter2:    getStrPtr(d, keyEdgeRuleActionParameter2),
+		ActionParameter1:    actionParam1,
+		ActionParameter2:    actionParam2,
 		Triggers:            triggers,
 		TriggerMatchingType: &matchingType,
-		Description:         getStrPtr(d, keyEdgeRuleDescription),
+		Description:         m.getStrPtr(keyEdgeRuleDescription),
 	}, nil
 }
 
 func resourceEdgeRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	edgeRuleGUID := d.Id()
 	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
 
+	pullZoneMu.Lock(pullZoneID)
+	defer pullZoneMu.Unlock(pullZoneID)
+
 	err := clt.PullZone.DeleteEdgeRule(ctx, pullZoneID, edgeRuleGUID)
+	clt.pullZoneCache.Invalidate(pullZoneID)
 	if err != nil {
 		return diagsErrFromErr("deleting edge rule failed", err)
 	}
@@ -348,21 +409,18 @@ func resourceEdgeRuleDelete(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceEdgeRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	edgeRuleGUID := d.Id()
 	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
 
-	pz, err := clt.PullZone.Get(ctx, pullZoneID)
+	pz, err := clt.pullZoneCache.Get(ctx, pullZoneID)
 	if err != nil {
-		return diagsErrFromErr("retrieving pull zone failed", err)
-	}
+		if isNotFoundErr(err) {
+			return removeFromState(d, "edge rule", fmt.Errorf("retrieving pull zone failed: %w", err))
+		}
 
-	if len(pz.EdgeRules) == 0 {
-		return diag.Diagnostics{{
-			Severity: diag.Error,
-			Summary:  "pull zone has no edge rules",
-		}}
+		return diagsErrFromErr("retrieving pull zone failed", err)
 	}
 
 	for _, er := range pz.EdgeRules {
@@ -375,11 +433,7 @@ func resourceEdgeRuleRead(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	return diag.Diagnostics{{
-		Severity: diag.Error,
-		Summary:  "edge rule not found",
-		Detail:   fmt.Sprintf("pull zone with id %d, has no edge rule with guid: %q", pullZoneID, edgeRuleGUID),
-	}}
+	return removeFromState(d, "edge rule", fmt.Errorf("pull zone with id %d, has no edge rule with guid %q: %w", pullZoneID, edgeRuleGUID, errNotFound))
 }
 
 func edgeRuleToResource(edgeRule *bunny.EdgeRule, d *schema.ResourceData) error {
@@ -387,62 +441,105 @@ func edgeRuleToResource(edgeRule *bunny.EdgeRule, d *schema.ResourceData) error
 		return errors.New("guid is empty")
 	}
 
-	d.SetId(*edgeRule.GUID)
-
-	actionType, err := intStrMapGet(edgeRuleActionTypesInt, edgeRule.ActionType)
+	m, err := edgeRuleFlatten(edgeRule)
 	if err != nil {
-		return fmt.Errorf("%s: %w", keyEdgeRuleActionType, err)
+		return err
 	}
 
-	if err := d.Set(keyEdgeRuleActionType, actionType); err != nil {
-		return err
+	edgeRuleSelectActionRepresentation(m, edgeRuleToStructure(d))
+
+	d.SetId(*edgeRule.GUID)
+
+	for k, v := range m {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
 	}
 
-	if err := d.Set(keyEdgeRuleActionParameter1, edgeRule.ActionParameter1); err != nil {
-		return err
+	return nil
+}
+
+// edgeRuleSelectActionRepresentation removes either the typed action block or
+// the action parameters from the flattened edge rule m.
+// The typed action block is only kept if it is used in prev, the previous
+// state of the edge rule, or the action parameters are not,
+// otherwise configurations using action_parameter_1 and action_parameter_2
+// would have a diff. When the block is kept, the parameters are represented
+// by it and are removed.
+func edgeRuleSelectActionRepresentation(m, prev structure) {
+	action := edgeRuleActionsByType[m.getStr(keyEdgeRuleActionType)]
+	if action == nil || m[action.actionType] == nil {
+		return
+	}
+
+	block, _ := prev[action.actionType].([]interface{})
+	param1, _ := prev[keyEdgeRuleActionParameter1].(string)
+	param2, _ := prev[keyEdgeRuleActionParameter2].(string)
+
+	if len(block) != 0 || (param1 == "" && param2 == "") {
+		m[keyEdgeRuleActionParameter1] = ""
+		m[keyEdgeRuleActionParameter2] = ""
+	} else {
+		m[action.actionType] = nil
 	}
+}
 
-	if err := d.Set(keyEdgeRuleActionParameter2, edgeRule.ActionParameter2); err != nil {
-		return err
+// edgeRuleFlatten converts edgeRule to a map with the attributes of the
+// bunny_edgerule resource, except the pull zone ID.
+// The typed action block for the action type is set if it can represent the
+// action parameters, the blocks of the other action types are set to nil.
+func edgeRuleFlatten(edgeRule *bunny.EdgeRule) (structure, error) {
+	actionType, err := intStrMapGet(edgeRuleActionTypesInt, edgeRule.ActionType)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", keyEdgeRuleActionType, err)
 	}
 
-	err = edgeRuleTriggerToResource(edgeRule.Triggers, d)
+	triggers, err := edgeRuleTriggersFlatten(edgeRule.Triggers)
 	if err != nil {
-		return fmt.Errorf("converting triggers to resource data failed: %w", err)
+		return nil, fmt.Errorf("converting triggers failed: %w", err)
 	}
 
 	matchingType, err := intStrMapGet(edgeRuleMatchingTypesInt, edgeRule.TriggerMatchingType)
 	if err != nil {
-		return fmt.Errorf("%s: %w", keyEdgeRuleTriggerMatchingType, err)
+		return nil, fmt.Errorf("%s: %w", keyEdgeRuleTriggerMatchingType, err)
 	}
 
-	if err := d.Set(keyEdgeRuleTriggerMatchingType, matchingType); err != nil {
-		return err
+	res := structure{
+		keyEdgeRuleActionType:          actionType,
+		keyEdgeRuleActionParameter1:    ptr.GetString(edgeRule.ActionParameter1),
+		keyEdgeRuleActionParameter2:    ptr.GetString(edgeRule.ActionParameter2),
+		keyEdgeRuleTriggers:            triggers,
+		keyEdgeRuleTriggerMatchingType: matchingType,
+		keyEdgeRuleDescription:         edgeRule.Description,
+		keyEnabled:                     edgeRule.Enabled,
 	}
 
-	if err := d.Set(keyEdgeRuleDescription, edgeRule.Description); err != nil {
-		return err
+	for _, action := range edgeRuleActions {
+		res[action.actionType] = nil
 	}
 
-	if err := d.Set(keyEnabled, edgeRule.Enabled); err != nil {
-		return err
+	if action := edgeRuleActionsByType[actionType]; action != nil {
+		attrs := action.flatten(res.getStr(keyEdgeRuleActionParameter1), res.getStr(keyEdgeRuleActionParameter2))
+		if attrs != nil {
+			res[actionType] = []map[string]interface{}{attrs}
+		}
 	}
 
-	return nil
+	return res, nil
 }
 
-func edgeRuleTriggerToResource(triggers []*bunny.EdgeRuleTrigger, d *schema.ResourceData) error {
+func edgeRuleTriggersFlatten(triggers []*bunny.EdgeRuleTrigger) ([]map[string]interface{}, error) {
 	res := make([]map[string]interface{}, 0, len(triggers))
 
 	for _, trigger := range triggers {
 		triggerType, err := intStrMapGet(edgeRuleTriggerTypesInt, trigger.Type)
 		if err != nil {
-			return fmt.Errorf("%s: %w", triggerType, err)
+			return nil, fmt.Errorf("%s: %w", triggerType, err)
 		}
 
 		patternMatchingType, err := intStrMapGet(edgeRuleMatchingTypesInt, trigger.PatternMatchingType)
 		if err != nil {
-			return fmt.Errorf("%s: %w", triggerType, err)
+			return nil, fmt.Errorf("%s: %w", triggerType, err)
 		}
 
 		entry := make(map[string]interface{}, 4)
@@ -454,5 +551,5 @@ func edgeRuleTriggerToResource(triggers []*bunny.EdgeRuleTrigger, d *schema.Reso
 		res = append(res, entry)
 	}
 
-	return d.Set(keyEdgeRuleTriggers, res)
+	return res, nil
 }
@@ -9,7 +9,10 @@ import (
 	"testing"
 
 	ptr "github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	bunny "github.com/simplesurance/bunny-go"
@@ -106,7 +109,7 @@ resource "bunny_pullzone" "mypz" {
 	origin_url ="https://bunny.net"
 }`, pzName)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -205,7 +208,7 @@ resource "bunny_edgerule" "er1" {
 	trigger {
 		pattern_matching_type = "none"
 		type = "country_code"
-		pattern_matches = ["de","dk"]
+		pattern_matches = ["DE","DK"]
 	}
 }
 
@@ -273,7 +276,7 @@ resource "bunny_edgerule" "er3" {
 								{
 									Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeCountryCode),
 									PatternMatchingType: ptr.ToInt(bunny.MatchingTypeNone),
-									PatternMatches:      []string{"de", "dk"},
+									PatternMatches:      []string{"DE", "DK"},
 								},
 							},
 						},
@@ -327,7 +330,7 @@ resource "bunny_edgerule" "myer" {
 	}
 } `, pzName)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -399,7 +402,7 @@ resource "bunny_pullzone" "mypz" {
 	}
 }`
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -445,7 +448,7 @@ resource "bunny_pullzone" "mypz" {
 	origin_url ="https://bunny.net"
 }`, pzName)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -573,6 +576,128 @@ resource "bunny_edgerule" "er2" {
 	})
 }
 
+func TestAccEdgeRule_newerActionAndTriggerTypes(t *testing.T) {
+	pzName := randResourceName()
+
+	tf := fmt.Sprintf(`
+resource "bunny_pullzone" "mypz" {
+	name = "%s"
+	origin_url ="https://bunny.net"
+}
+
+resource "bunny_edgerule" "er1" {
+	pull_zone_id = bunny_pullzone.mypz.id
+	action_type = "disable_waf"
+	trigger_matching_type = "all"
+	trigger {
+		type = "cookie_value"
+		parameter_1 = "session"
+		pattern_matching_type = "any"
+		pattern_matches = ["internal-*"]
+	}
+}
+
+resource "bunny_edgerule" "er2" {
+	pull_zone_id = bunny_pullzone.mypz.id
+	action_type = "set_requests_per_second_limit"
+	action_parameter_1 = "10"
+	trigger_matching_type = "any"
+	trigger {
+		type = "country_state_code"
+		pattern_matching_type = "any"
+		pattern_matches = ["US-CA", "US-NY"]
+	}
+}
+
+resource "bunny_edgerule" "er3" {
+	pull_zone_id = bunny_pullzone.mypz.id
+	action_type = "remove_browser_cache_response_header"
+	trigger_matching_type = "all"
+	trigger {
+		type = "origin_retry_attempt_count"
+		pattern_matching_type = "any"
+		pattern_matches = ["1"]
+	}
+}
+`, pzName)
+
+	accTest(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf,
+				Check: checkEdgeRulesState(t, &edgeRulesWanted{
+					TerraformPullZoneResourceName: "bunny_pullzone.mypz",
+					PullZoneName:                  pzName,
+					EdgeRules: []*bunny.EdgeRule{
+						{
+							Enabled:             ptr.ToBool(true),
+							ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeDisableWAF),
+							TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+							ActionParameter1:    ptr.ToString(""),
+							ActionParameter2:    ptr.ToString(""),
+							Triggers: []*bunny.EdgeRuleTrigger{
+								{
+									Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeCookieValue),
+									Parameter1:          ptr.ToString("session"),
+									PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+									PatternMatches:      []string{"internal-*"},
+								},
+							},
+						},
+						{
+							Enabled:             ptr.ToBool(true),
+							ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeSetRequestsPerSecondLimit),
+							TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+							ActionParameter1:    ptr.ToString("10"),
+							ActionParameter2:    ptr.ToString(""),
+							Triggers: []*bunny.EdgeRuleTrigger{
+								{
+									Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeCountryStateCode),
+									PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+									PatternMatches:      []string{"US-CA", "US-NY"},
+								},
+							},
+						},
+						{
+							Enabled:             ptr.ToBool(true),
+							ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeRemoveBrowserCacheResponseHeader),
+							TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+							ActionParameter1:    ptr.ToString(""),
+							ActionParameter2:    ptr.ToString(""),
+							Triggers: []*bunny.EdgeRuleTrigger{
+								{
+									Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeOriginRetryAttemptCount),
+									PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+									PatternMatches:      []string{"1"},
+								},
+							},
+						},
+					},
+				}),
+			},
+			{
+				ResourceName:      "bunny_edgerule.er1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					pzID, err := idFromState(s, "bunny_pullzone.mypz")
+					if err != nil {
+						return "", fmt.Errorf("could not get pull zone id from state: %w", err)
+					}
+					edgeruleID, err := idFromState(s, "bunny_edgerule.er1")
+					if err != nil {
+						return "", fmt.Errorf("could not get edgerule id from state: %w", err)
+					}
+
+					return fmt.Sprintf("%s/%s", pzID, edgeruleID), nil
+				},
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
 func TestAccEdgeRule_changePullZoneID(t *testing.T) {
 	pzName1 := randResourceName()
 	pzName2 := randResourceName()
@@ -588,7 +713,7 @@ resource "bunny_pullzone" "pz2" {
 	origin_url ="https://bunny.net"
 } `, pzName1, pzName2)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -622,3 +747,693 @@ resource "bunny_edgerule" "er1" {
 		},
 	})
 }
+
+func TestFakeAPIEdgeRule_createReadUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeRule().Schema, map[string]interface{}{
+		keyEdgeRulePullZoneID:          int(pzID),
+		keyEdgeRuleActionType:          "block_request",
+		keyEdgeRuleTriggerMatchingType: "all",
+		keyEdgeRuleTriggers: []interface{}{
+			map[string]interface{}{
+				keyEdgeRuleTriggerType:                "random_chance",
+				keyEdgeRuleTriggerPatternMatchingType: "any",
+				keyEdgeRuleTriggerPatternMatches:      []interface{}{"30"},
+			},
+		},
+	})
+
+	if diags := resourceEdgeRuleCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	pz, _ := srv.PullZone(pzID)
+	if len(pz.EdgeRules) != 1 || ptr.GetString(pz.EdgeRules[0].GUID) != d.Id() {
+		t.Fatalf("expected pull zone to have 1 edge rule with guid %q, has: %+v", d.Id(), pz.EdgeRules)
+	}
+
+	if diags := resourceEdgeRuleRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read failed: %+v", diags)
+	}
+
+	if err := d.Set(keyEdgeRuleActionType, "force_ssl"); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceEdgeRuleUpdate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update failed: %+v", diags)
+	}
+
+	pz, _ = srv.PullZone(pzID)
+	if len(pz.EdgeRules) != 1 || ptr.GetInt(pz.EdgeRules[0].ActionType) != bunny.EdgeRuleActionTypeForceSSL {
+		t.Fatalf("expected edge rule action type to be updated, edge rules: %+v", pz.EdgeRules)
+	}
+
+	if diags := resourceEdgeRuleDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete failed: %+v", diags)
+	}
+
+	pz, _ = srv.PullZone(pzID)
+	if len(pz.EdgeRules) != 0 {
+		t.Errorf("edge rule still exists after deletion")
+	}
+}
+
+func TestFakeAPIEdgeRule_readRemovesDeletedEdgeRule(t *testing.T) {
+	ctx := context.Background()
+	_, meta := newFakeAPIProvider(t)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	t.Run("edge rule deleted", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceEdgeRule().Schema, map[string]interface{}{
+			keyEdgeRulePullZoneID: int(pzID),
+		})
+		d.SetId(uuid.New().String())
+
+		assertRemovedFromState(t, d, resourceEdgeRuleRead(ctx, d, meta))
+	})
+
+	t.Run("pull zone deleted", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceEdgeRule().Schema, map[string]interface{}{
+			keyEdgeRulePullZoneID: int(pzID) + 1000,
+		})
+		d.SetId(uuid.New().String())
+
+		assertRemovedFromState(t, d, resourceEdgeRuleRead(ctx, d, meta))
+	})
+}
+
+func TestFakeAPIEdgeRule_typedActionBlocks(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	testcases := []struct {
+		actionType string
+		block      map[string]interface{}
+		wantParam1 string
+		wantParam2 string
+	}{
+		{
+			actionType: "redirect",
+			block:      map[string]interface{}{keyEdgeRuleActionURL: "https://example.com{{path}}", keyEdgeRuleActionStatusCode: 308},
+			wantParam1: "https://example.com{{path}}",
+			wantParam2: "308",
+		},
+		{
+			actionType: "origin_url",
+			block:      map[string]interface{}{keyEdgeRuleActionURL: "https://origin.example.com"},
+			wantParam1: "https://origin.example.com",
+		},
+		{
+			actionType: "override_cache_time",
+			block:      map[string]interface{}{keyEdgeRuleActionSeconds: 3600},
+			wantParam1: "3600",
+		},
+		{
+			actionType: "override_cache_time_public",
+			block:      map[string]interface{}{keyEdgeRuleActionSeconds: 0},
+			wantParam1: "0",
+		},
+		{
+			actionType: "set_response_header",
+			block:      map[string]interface{}{keyEdgeRuleActionHeaderName: "X-Frame-Options", keyEdgeRuleActionHeaderValue: "DENY"},
+			wantParam1: "X-Frame-Options",
+			wantParam2: "DENY",
+		},
+		{
+			actionType: "set_request_header",
+			block:      map[string]interface{}{keyEdgeRuleActionHeaderName: "X-Forwarded-Host", keyEdgeRuleActionHeaderValue: ""},
+			wantParam1: "X-Forwarded-Host",
+		},
+		{
+			actionType: "set_status_code",
+			block:      map[string]interface{}{keyEdgeRuleActionStatusCode: 410},
+			wantParam1: "410",
+		},
+	}
+
+	if len(testcases) != len(edgeRuleActions) {
+		t.Fatalf("testcases do not cover all %d action blocks", len(edgeRuleActions))
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.actionType, func(t *testing.T) {
+			config := map[string]interface{}{
+				keyEdgeRulePullZoneID:          int(pzID),
+				tc.actionType:                  []interface{}{tc.block},
+				keyEdgeRuleTriggerMatchingType: "all",
+				keyEdgeRuleTriggers: []interface{}{
+					map[string]interface{}{
+						keyEdgeRuleTriggerType:                "url",
+						keyEdgeRuleTriggerPatternMatchingType: "any",
+						keyEdgeRuleTriggerPatternMatches:      []interface{}{"*/" + tc.actionType},
+					},
+				},
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceEdgeRule().Schema, config)
+
+			if diags := resourceEdgeRuleCreate(ctx, d, meta); diags.HasError() {
+				t.Fatalf("create failed: %+v", diags)
+			}
+
+			pz, _ := srv.PullZone(pzID)
+			er := pz.EdgeRules[findEdgeRuleIdx(t, pz, d.Id())]
+
+			if ptr.GetInt(er.ActionType) != edgeRuleActionTypesStr[tc.actionType] {
+				t.Errorf("action type is %d, expected %d", ptr.GetInt(er.ActionType), edgeRuleActionTypesStr[tc.actionType])
+			}
+
+			if ptr.GetString(er.ActionParameter1) != tc.wantParam1 {
+				t.Errorf("action parameter 1 is %q, expected %q", ptr.GetString(er.ActionParameter1), tc.wantParam1)
+			}
+
+			if ptr.GetString(er.ActionParameter2) != tc.wantParam2 {
+				t.Errorf("action parameter 2 is %q, expected %q", ptr.GetString(er.ActionParameter2), tc.wantParam2)
+			}
+
+			// imported edge rules use the typed action block
+			imported := schema.TestResourceDataRaw(t, resourceEdgeRule().Schema, map[string]interface{}{})
+			imported.SetId(fmt.Sprintf("%d/%s", pzID, d.Id()))
+			if _, err := resourceEdgeRuleImport(ctx, imported, meta); err != nil {
+				t.Fatalf("import failed: %s", err)
+			}
+
+			if actionType := imported.Get(keyEdgeRuleActionType).(string); actionType != tc.actionType {
+				t.Errorf("%s is %q, expected %q", keyEdgeRuleActionType, actionType, tc.actionType)
+			}
+
+			for k, want := range tc.block {
+				key := tc.actionType + ".0." + k
+				if got := imported.Get(key); got != want {
+					t.Errorf("%s is %v, expected %v", key, got, want)
+				}
+			}
+
+			if p1 := imported.Get(keyEdgeRuleActionParameter1).(string); p1 != "" {
+				t.Errorf("%s is %q, expected it to be empty when the action block is used", keyEdgeRuleActionParameter1, p1)
+			}
+
+			diff, err := resourceEdgeRule().Diff(ctx, imported.State(), terraform.NewResourceConfigRaw(config), meta)
+			if err != nil {
+				t.Fatalf("planning failed: %s", err)
+			}
+
+			if diff != nil && len(diff.Attributes) != 0 {
+				for k, attr := range diff.Attributes {
+					t.Errorf("unexpected diff for %s: %+v", k, attr)
+				}
+			}
+		})
+	}
+}
+
+func TestFakeAPIEdgeRule_actionParameters(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeRule().Schema, map[string]interface{}{
+		keyEdgeRulePullZoneID:          int(pzID),
+		keyEdgeRuleActionType:          "set_response_header",
+		keyEdgeRuleActionParameter1:    "Cache-Control",
+		keyEdgeRuleActionParameter2:    "no-store",
+		keyEdgeRuleTriggerMatchingType: "all",
+		keyEdgeRuleTriggers: []interface{}{
+			map[string]interface{}{
+				keyEdgeRuleTriggerType:                "url",
+				keyEdgeRuleTriggerPatternMatchingType: "any",
+				keyEdgeRuleTriggerPatternMatches:      []interface{}{"*"},
+			},
+		},
+	})
+
+	if diags := resourceEdgeRuleCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	pz, _ := srv.PullZone(pzID)
+	er := pz.EdgeRules[findEdgeRuleIdx(t, pz, d.Id())]
+	if ptr.GetString(er.ActionParameter1) != "Cache-Control" || ptr.GetString(er.ActionParameter2) != "no-store" {
+		t.Errorf("unexpected action parameters: %q, %q", ptr.GetString(er.ActionParameter1), ptr.GetString(er.ActionParameter2))
+	}
+
+	if diags := resourceEdgeRuleRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read failed: %+v", diags)
+	}
+
+	if p1 := d.Get(keyEdgeRuleActionParameter1).(string); p1 != "Cache-Control" {
+		t.Errorf("%s is %q, expected Cache-Control", keyEdgeRuleActionParameter1, p1)
+	}
+
+	if blocks := d.Get("set_response_header").([]interface{}); len(blocks) != 0 {
+		t.Errorf("set_response_header block is stored in the state when the action parameters are used: %+v", blocks)
+	}
+}
+
+func findEdgeRuleIdx(t *testing.T, pz *bunny.PullZone, guid string) int {
+	t.Helper()
+
+	for i, er := range pz.EdgeRules {
+		if ptr.GetString(er.GUID) == guid {
+			return i
+		}
+	}
+
+	t.Fatalf("pull zone has no edge rule with guid %q", guid)
+	return -1
+}
+
+func TestEdgeRule_actionValidation(t *testing.T) {
+	redirect := []interface{}{map[string]interface{}{keyEdgeRuleActionURL: "https://example.com"}}
+
+	testcases := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "action type without parameters",
+			config: map[string]interface{}{keyEdgeRuleActionType: "block_request"},
+		},
+		{
+			name:   "action block",
+			config: map[string]interface{}{"redirect": redirect},
+		},
+		{
+			name:   "action block with matching action type",
+			config: map[string]interface{}{keyEdgeRuleActionType: "redirect", "redirect": redirect},
+		},
+		{
+			name: "action parameters",
+			config: map[string]interface{}{
+				keyEdgeRuleActionType:       "redirect",
+				keyEdgeRuleActionParameter1: "https://example.com",
+			},
+		},
+		{
+			name:    "no action",
+			config:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:    "action block with other action type",
+			config:  map[string]interface{}{keyEdgeRuleActionType: "force_ssl", "redirect": redirect},
+			wantErr: true,
+		},
+		{
+			name: "multiple action blocks",
+			config: map[string]interface{}{
+				"redirect":   redirect,
+				"origin_url": []interface{}{map[string]interface{}{keyEdgeRuleActionURL: "https://example.com"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "action block with action parameter",
+			config: map[string]interface{}{
+				"redirect":                  redirect,
+				keyEdgeRuleActionParameter2: "302",
+			},
+			wantErr: true,
+		},
+		{
+			name: "redirect to url without scheme",
+			config: map[string]interface{}{
+				"redirect": []interface{}{map[string]interface{}{keyEdgeRuleActionURL: "example.com"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "redirect with non-redirect status code",
+			config: map[string]interface{}{
+				"redirect": []interface{}{map[string]interface{}{
+					keyEdgeRuleActionURL:        "https://example.com",
+					keyEdgeRuleActionStatusCode: 200,
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative cache time",
+			config: map[string]interface{}{
+				"override_cache_time": []interface{}{map[string]interface{}{keyEdgeRuleActionSeconds: -1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid header name",
+			config: map[string]interface{}{
+				"set_response_header": []interface{}{map[string]interface{}{
+					keyEdgeRuleActionHeaderName:  "X Frame Options",
+					keyEdgeRuleActionHeaderValue: "DENY",
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid status code",
+			config: map[string]interface{}{
+				"set_status_code": []interface{}{map[string]interface{}{keyEdgeRuleActionStatusCode: 1000}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config[keyEdgeRulePullZoneID] = 1
+			tc.config[keyEdgeRuleTriggers] = []interface{}{
+				map[string]interface{}{
+					keyEdgeRuleTriggerType:                "url",
+					keyEdgeRuleTriggerPatternMatchingType: "any",
+					keyEdgeRuleTriggerPatternMatches:      []interface{}{"*"},
+				},
+			}
+
+			res := resourceEdgeRule()
+			cfg := terraform.NewResourceConfigRaw(tc.config)
+
+			var err error
+			if diags := res.Validate(cfg); diags.HasError() {
+				err = fmt.Errorf("validation failed: %+v", diags)
+			} else {
+				_, err = res.Diff(context.Background(), nil, cfg, nil)
+			}
+
+			if tc.wantErr && err == nil {
+				t.Fatal("planning succeeded, expected an error")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("planning failed: %s", err)
+			}
+		})
+	}
+}
+
+func TestFakeAPIEdgeRule_importAllActionAndTriggerTypes(t *testing.T) {
+	ctx := context.Background()
+	_, meta := newFakeAPIProvider(t)
+	clt := meta.(*client)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	for i, actionType := range edgeRuleActionTypeKeys {
+		// edge rules with every action and trigger type can be created
+		// in the bunny.net panel, they must be importable
+		triggerType := edgeRuleTriggerTypeKeys[i%len(edgeRuleTriggerTypeKeys)]
+
+		t.Run(actionType, func(t *testing.T) {
+			description := uuid.New().String()
+
+			err := clt.PullZone.AddOrUpdateEdgeRule(ctx, pzID, &bunny.AddOrUpdateEdgeRuleOptions{
+				ActionType:          ptr.ToInt(edgeRuleActionTypesStr[actionType]),
+				TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+				Description:         &description,
+				Triggers: []*bunny.EdgeRuleTrigger{
+					{
+						Type:                ptr.ToInt(edgeRuleTriggerTypesStr[triggerType]),
+						PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+						PatternMatches:      []string{"1"},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clt.pullZoneCache.Invalidate(pzID)
+
+			guid, err := findEdgeRuleGUID(ctx, clt, pzID, description)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceEdgeRule().Schema, map[string]interface{}{})
+			d.SetId(fmt.Sprintf("%d/%s", pzID, guid))
+
+			if _, err := resourceEdgeRuleImport(ctx, d, meta); err != nil {
+				t.Fatalf("import failed: %s", err)
+			}
+
+			if d.Id() != guid {
+				t.Fatalf("edge rule was not imported, id is %q", d.Id())
+			}
+
+			if v := d.Get(keyEdgeRuleActionType).(string); v != actionType {
+				t.Errorf("%s is %q, expected %q", keyEdgeRuleActionType, v, actionType)
+			}
+
+			triggers := d.Get(keyEdgeRuleTriggers).(*schema.Set).List()
+			if len(triggers) != 1 {
+				t.Fatalf("expected 1 trigger, got: %+v", triggers)
+			}
+
+			if v := triggers[0].(map[string]interface{})[keyEdgeRuleTriggerType]; v != triggerType {
+				t.Errorf("trigger type is %q, expected %q", v, triggerType)
+			}
+		})
+	}
+}
+
+func TestEdgeRule_expandFlattenNewerActionAndTriggerTypes(t *testing.T) {
+	testcases := []struct {
+		actionType       string
+		triggerType      string
+		apiActionType    int
+		apiTriggerType   int
+		triggerParameter string
+	}{
+		{actionType: "override_browser_cache_time", apiActionType: bunny.EdgeRuleActionTypeOverrideBrowserCacheTime},
+		{actionType: "origin_storage", apiActionType: bunny.EdgeRuleActionTypeOriginStorage},
+		{actionType: "set_network_rate_limit", apiActionType: bunny.EdgeRuleActionTypeSetNetworkRateLimit},
+		{actionType: "set_connection_limit", apiActionType: bunny.EdgeRuleActionTypeSetConnectionLimit},
+		{actionType: "set_requests_per_second_limit", apiActionType: bunny.EdgeRuleActionTypeSetRequestsPerSecondLimit},
+		{actionType: "run_edge_script", apiActionType: bunny.EdgeRuleActionTypeRunEdgeScript},
+		{actionType: "origin_magic_containers", apiActionType: bunny.EdgeRuleActionTypeOriginMagicContainers},
+		{actionType: "disable_waf", apiActionType: bunny.EdgeRuleActionTypeDisableWAF},
+		{actionType: "retry_origin", apiActionType: bunny.EdgeRuleActionTypeRetryOrigin},
+		{actionType: "override_browser_cache_response_header", apiActionType: bunny.EdgeRuleActionTypeOverrideBrowserCacheResponseHeader},
+		{actionType: "remove_browser_cache_response_header", apiActionType: bunny.EdgeRuleActionTypeRemoveBrowserCacheResponseHeader},
+		{triggerType: "cookie_value", apiTriggerType: bunny.EdgeRuleTriggerTypeCookieValue, triggerParameter: "session"},
+		{triggerType: "country_state_code", apiTriggerType: bunny.EdgeRuleTriggerTypeCountryStateCode},
+		{triggerType: "origin_retry_attempt_count", apiTriggerType: bunny.EdgeRuleTriggerTypeOriginRetryAttemptCount},
+	}
+
+	for _, tc := range testcases {
+		if tc.actionType == "" {
+			tc.actionType = "block_request"
+			tc.apiActionType = bunny.EdgeRuleActionTypeBlockRequest
+		}
+
+		if tc.triggerType == "" {
+			tc.triggerType = "url"
+			tc.apiTriggerType = bunny.EdgeRuleTriggerTypeURL
+		}
+
+		t.Run(tc.actionType+"/"+tc.triggerType, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceEdgeRule().Schema, map[string]interface{}{
+				keyEdgeRulePullZoneID:          1,
+				keyEdgeRuleActionType:          tc.actionType,
+				keyEdgeRuleActionParameter1:    "p1",
+				keyEdgeRuleActionParameter2:    "p2",
+				keyEdgeRuleTriggerMatchingType: "all",
+				keyEdgeRuleTriggers: []interface{}{
+					map[string]interface{}{
+						keyEdgeRuleTriggerType:                tc.triggerType,
+						keyEdgeRuleTriggerPatternMatchingType: "any",
+						keyEdgeRuleTriggerPatternMatches:      []interface{}{"1"},
+						keyEdgeRuleTriggerParameter1:          tc.triggerParameter,
+					},
+				},
+			})
+
+			opts, err := edgeRuleExpand(nil, edgeRuleToStructure(d))
+			if err != nil {
+				t.Fatalf("expand failed: %s", err)
+			}
+
+			if v := ptr.GetInt(opts.ActionType); v != tc.apiActionType {
+				t.Errorf("ActionType is %d, expected %d", v, tc.apiActionType)
+			}
+
+			if len(opts.Triggers) != 1 {
+				t.Fatalf("expected 1 trigger, got: %+v", opts.Triggers)
+			}
+
+			if v := ptr.GetInt(opts.Triggers[0].Type); v != tc.apiTriggerType {
+				t.Errorf("trigger Type is %d, expected %d", v, tc.apiTriggerType)
+			}
+
+			edgeRule := bunny.EdgeRule(*opts)
+			m, err := edgeRuleFlatten(&edgeRule)
+			if err != nil {
+				t.Fatalf("flatten failed: %s", err)
+			}
+
+			if v := m.getStr(keyEdgeRuleActionType); v != tc.actionType {
+				t.Errorf("%s is %q, expected %q", keyEdgeRuleActionType, v, tc.actionType)
+			}
+
+			if p1, p2 := m.getStr(keyEdgeRuleActionParameter1), m.getStr(keyEdgeRuleActionParameter2); p1 != "p1" || p2 != "p2" {
+				t.Errorf("action parameters are %q, %q, expected \"p1\", \"p2\"", p1, p2)
+			}
+
+			triggers := m[keyEdgeRuleTriggers].([]map[string]interface{})
+			if len(triggers) != 1 {
+				t.Fatalf("expected 1 flattened trigger, got: %+v", triggers)
+			}
+
+			if v := triggers[0][keyEdgeRuleTriggerType]; v != tc.triggerType {
+				t.Errorf("trigger type is %q, expected %q", v, tc.triggerType)
+			}
+
+			if v := ptr.GetString(triggers[0][keyEdgeRuleTriggerParameter1].(*string)); v != tc.triggerParameter {
+				t.Errorf("trigger parameter is %q, expected %q", v, tc.triggerParameter)
+			}
+		})
+	}
+}
+
+func TestEdgeRule_triggerPatternValidation(t *testing.T) {
+	testcases := []struct {
+		triggerType string
+		patterns    []interface{}
+		wantErr     bool
+	}{
+		{triggerType: "country_code", patterns: []interface{}{"DE", "US", "XK"}},
+		{triggerType: "country_code", patterns: []interface{}{"de", "dk"}, wantErr: true},
+		{triggerType: "country_code", patterns: []interface{}{"XX"}, wantErr: true},
+		{triggerType: "country_state_code", patterns: []interface{}{"US-CA", "DE-BY"}},
+		{triggerType: "country_state_code", patterns: []interface{}{"us-ca"}, wantErr: true},
+		{triggerType: "country_state_code", patterns: []interface{}{"US"}, wantErr: true},
+		{triggerType: "country_state_code", patterns: []interface{}{"XX-CA"}, wantErr: true},
+		{triggerType: "remote_ip", patterns: []interface{}{"10.0.0.1", "192.168.0.0/16", "2001:db8::/32"}},
+		{triggerType: "remote_ip", patterns: []interface{}{"10.0.0.0/33"}, wantErr: true},
+		{triggerType: "remote_ip", patterns: []interface{}{"localhost"}, wantErr: true},
+		{triggerType: "status_code", patterns: []interface{}{"404", "503"}},
+		{triggerType: "status_code", patterns: []interface{}{"4xx"}, wantErr: true},
+		{triggerType: "status_code", patterns: []interface{}{"99"}, wantErr: true},
+		{triggerType: "request_method", patterns: []interface{}{"GET", "POST"}},
+		{triggerType: "request_method", patterns: []interface{}{"get"}, wantErr: true},
+		{triggerType: "request_method", patterns: []interface{}{"FETCH"}, wantErr: true},
+		{triggerType: "random_chance", patterns: []interface{}{"0", "50", "100"}},
+		{triggerType: "random_chance", patterns: []interface{}{"101"}, wantErr: true},
+		{triggerType: "random_chance", patterns: []interface{}{"50%"}, wantErr: true},
+		{triggerType: "origin_retry_attempt_count", patterns: []interface{}{"2"}},
+		{triggerType: "origin_retry_attempt_count", patterns: []interface{}{"-1"}, wantErr: true},
+		{triggerType: "url", patterns: []interface{}{"*", "de", "not a country"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s %v", tc.triggerType, tc.patterns), func(t *testing.T) {
+			trigger := map[string]interface{}{
+				keyEdgeRuleTriggerType:                tc.triggerType,
+				keyEdgeRuleTriggerPatternMatchingType: "any",
+				keyEdgeRuleTriggerPatternMatches:      tc.patterns,
+			}
+
+			// assertErr ensures that err is returned if an error is
+			// expected and that it names the attribute path, the trigger
+			// type and the pattern
+			assertErr := func(t *testing.T, err error, prefix string) {
+				t.Helper()
+
+				if tc.wantErr && err == nil {
+					t.Fatal("planning succeeded, expected an error")
+				}
+
+				if !tc.wantErr && err != nil {
+					t.Fatalf("planning failed: %s", err)
+				}
+
+				if err == nil {
+					return
+				}
+
+				wantPrefix := prefix + keyEdgeRuleTriggers + ".0." + keyEdgeRuleTriggerPatternMatches + "."
+				if !strings.HasPrefix(err.Error(), wantPrefix) {
+					t.Errorf("error does not start with %q: %s", wantPrefix, err)
+				}
+
+				if !strings.Contains(err.Error(), tc.triggerType) {
+					t.Errorf("error does not contain the trigger type %q: %s", tc.triggerType, err)
+				}
+
+				if !strings.Contains(err.Error(), tc.patterns[0].(string)) {
+					t.Errorf("error does not contain the pattern %q: %s", tc.patterns[0], err)
+				}
+			}
+
+			err := testPlanCreate(t, "bunny_edgerule", map[string]interface{}{
+				keyEdgeRulePullZoneID: 1,
+				keyEdgeRuleActionType: "block_request",
+				keyEdgeRuleTriggers:   []interface{}{trigger},
+			})
+			assertErr(t, err, "")
+
+			err = testPlanCreate(t, "bunny_pullzone_edgerules", map[string]interface{}{
+				keyPullZoneEdgeRulesPullZoneID: 1,
+				keyPullZoneEdgeRulesEdgeRule: []interface{}{
+					map[string]interface{}{
+						keyEdgeRuleActionType: "block_request",
+						keyEdgeRuleTriggers:   []interface{}{trigger},
+					},
+				},
+			})
+			assertErr(t, err, keyPullZoneEdgeRulesEdgeRule+".0.")
+		})
+	}
+}
+
+func TestEdgeRule_triggerPatternValidationSkipsUnknownValues(t *testing.T) {
+	trigger := func(triggerType, patterns cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			keyEdgeRuleTriggers: cty.SetVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					keyEdgeRuleTriggerType:           triggerType,
+					keyEdgeRuleTriggerPatternMatches: patterns,
+				}),
+			}),
+		})
+	}
+
+	for name, rawEdgeRule := range map[string]cty.Value{
+		"unknown pattern": trigger(
+			cty.StringVal("remote_ip"),
+			cty.SetVal([]cty.Value{cty.UnknownVal(cty.String)}),
+		),
+		"unknown patterns": trigger(
+			cty.StringVal("remote_ip"),
+			cty.UnknownVal(cty.Set(cty.String)),
+		),
+		"unknown trigger type": trigger(
+			cty.UnknownVal(cty.String),
+			cty.SetVal([]cty.Value{cty.StringVal("localhost")}),
+		),
+		"unknown triggers": cty.ObjectVal(map[string]cty.Value{
+			keyEdgeRuleTriggers: cty.UnknownVal(cty.Set(cty.Object(map[string]cty.Type{
+				keyEdgeRuleTriggerType:           cty.String,
+				keyEdgeRuleTriggerPatternMatches: cty.Set(cty.String),
+			}))),
+		}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := validateEdgeRuleTriggerPatterns(rawEdgeRule, ""); err != nil {
+				t.Errorf("validation failed: %s", err)
+			}
+		})
+	}
+
+	err := validateEdgeRuleTriggerPatterns(trigger(
+		cty.StringVal("remote_ip"),
+		cty.SetVal([]cty.Value{cty.UnknownVal(cty.String), cty.StringVal("localhost")}),
+	), "")
+	if err == nil {
+		t.Error("validation succeeded, expected the known invalid pattern to be rejected")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	ptr "github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,6 +24,13 @@ const (
 	keyHostnameHasCertificate      = "has_certificate"
 	keyHostnameLoadFreeCertificate = "load_free_certificate"
 	keyHostnameCertificate         = "certificate"
+	keyHostnameCertificateStatus   = "certificate_status"
+)
+
+const (
+	certificateStatusActive  = "active"
+	certificateStatusPending = "pending"
+	certificateStatusNone    = "none"
 )
 
 const (
@@ -38,6 +46,7 @@ func resourceHostname() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceHostnameImport,
 		},
+		Timeouts: defaultResourceTimeouts(),
 
 		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
 			loadFreeCert := d.Get(keyHostnameLoadFreeCertificate).(bool)
@@ -79,12 +88,23 @@ func resourceHostname() *schema.Resource {
 				Description: "Determines if the hostname has an SSL certificate configured.",
 				Computed:    true,
 			},
+			keyHostnameCertificateStatus: {
+				Type: schema.TypeString,
+				Description: fmt.Sprintf(
+					"The status of the SSL certificate of the hostname. `%s` if a certificate is configured, `%s` if %s is enabled for the pull zone and the certificate was not issued yet, `%s` otherwise.",
+					certificateStatusActive, certificateStatusPending, keyAutoSSL, certificateStatusNone,
+				),
+				Computed: true,
+			},
 			keyHostnameLoadFreeCertificate: {
-				Type:        schema.TypeBool,
-				Description: "Determines if a free SSL certificate should be generated and loaded for the hostname",
-				ForceNew:    true,
-				Optional:    true,
-				Default:     false,
+				Type: schema.TypeBool,
+				Description: fmt.Sprintf(
+					"Determines if a free SSL certificate should be generated and loaded for the hostname. Ignored if %s is enabled for the pull zone, the certificate is then issued automatically.",
+					keyAutoSSL,
+				),
+				ForceNew: true,
+				Optional: true,
+				Default:  false,
 			},
 			keyHostnameCertificate: {
 				Type:        schema.TypeList,
@@ -99,12 +119,15 @@ func resourceHostname() *schema.Resource {
 }
 
 func resourceHostnameCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	pullZoneID := int64(d.Get(keyHostnamePullZoneID).(int))
 	hostnameOpt := resourceDataToAddCustomHostnameOption(d)
 
+	pullZoneMu.Lock(pullZoneID)
 	err := clt.PullZone.AddCustomHostname(ctx, pullZoneID, hostnameOpt)
+	clt.pullZoneCache.Invalidate(pullZoneID)
+	pullZoneMu.Unlock(pullZoneID)
 	if err != nil {
 		return diagsErrFromErr("could not add hostname", err)
 	}
@@ -112,9 +135,7 @@ func resourceHostnameCreate(ctx context.Context, d *schema.ResourceData, meta in
 	var diag diag.Diagnostics
 
 	if d.Get(keyHostnameLoadFreeCertificate).(bool) {
-		if err := loadFreeCertRetry(ctx, clt, d.Timeout(schema.TimeoutCreate), *hostnameOpt.Hostname); err != nil {
-			diag = diagsErrFromErr("creating hostname succeeded, loading free ssl certificate failed", err)
-		}
+		diag = loadFreeCertIfNoAutoSSL(ctx, clt, d.Timeout(schema.TimeoutCreate), pullZoneID, *hostnameOpt.Hostname)
 	}
 
 	if m := structureFromResource(d, keyHostnameCertificate); len(m) != 0 {
@@ -130,38 +151,73 @@ func resourceHostnameCreate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	if forceSSL := d.Get(keyHostnameForceSSL).(bool); forceSSL {
+		pullZoneMu.Lock(pullZoneID)
 		err = clt.PullZone.SetForceSSL(ctx, pullZoneID, &bunny.SetForceSSLOptions{
 			Hostname: hostnameOpt.Hostname,
 			ForceSSL: &forceSSL,
 		})
+		clt.pullZoneCache.Invalidate(pullZoneID)
+		pullZoneMu.Unlock(pullZoneID)
 		if err != nil {
 			diag = append(diag, diagsErrFromErr("creating hostname succeeded, enabling force_ssl failed", err)...)
 		}
 	}
 
-	hostname, err := resourceHostnameGetByName(ctx, clt, pullZoneID, *hostnameOpt.Hostname)
+	pz, hostname, err := resourceHostnameGetByName(ctx, clt, pullZoneID, *hostnameOpt.Hostname)
 	if err != nil {
 		return append(diag, diagsErrFromErr("creating hostname succeeded, retrieving it from api failed", err)...)
 	}
 
-	if err := hostnameToResource(hostname, d); err != nil {
+	if err := hostnameToResource(pz, hostname, d); err != nil {
 		return append(diag, diagsErrFromErr("converting hostname api type to terraform resource failed", err)...)
 	}
 
 	return diag
 }
 
-func uploadCertificate(ctx context.Context, clt *bunny.Client, pullZoneID int64, hostname string, m structure) error {
+func uploadCertificate(ctx context.Context, clt *client, pullZoneID int64, hostname string, m structure) error {
 	msg := bunny.PullZoneAddCustomCertificateOptions{
 		Hostname:       hostname,
 		Certificate:    []byte(m.getStr(keyCertificateCertificateData)),
 		CertificateKey: []byte(m.getStr(keyCertificatePrivateKeyData)),
 	}
 
+	pullZoneMu.Lock(pullZoneID)
+	defer pullZoneMu.Unlock(pullZoneID)
+	defer clt.pullZoneCache.Invalidate(pullZoneID)
+
 	return clt.PullZone.AddCustomCertificate(ctx, pullZoneID, &msg)
 }
 
-func loadFreeCertRetry(ctx context.Context, clt *bunny.Client, timeout time.Duration, hostname string) error {
+// loadFreeCertIfNoAutoSSL loads a free certificate for hostname, if auto SSL
+// is disabled for the pull zone.
+// If auto SSL is enabled, bunny.net issues the certificate and a warning is
+// returned instead.
+func loadFreeCertIfNoAutoSSL(ctx context.Context, clt *client, timeout time.Duration, pullZoneID int64, hostname string) diag.Diagnostics {
+	pz, err := clt.pullZoneCache.Get(ctx, pullZoneID)
+	if err != nil {
+		return diagsErrFromErr("creating hostname succeeded, retrieving pull zone failed", err)
+	}
+
+	if ptr.GetBool(pz.EnableAutoSSL) {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s is ignored", keyHostnameLoadFreeCertificate),
+			Detail: fmt.Sprintf(
+				"%s is enabled for pull zone %d, the certificate for hostname %q is issued automatically.",
+				keyAutoSSL, pullZoneID, hostname,
+			),
+		}}
+	}
+
+	if err := loadFreeCertRetry(ctx, clt, timeout, pullZoneID, hostname); err != nil {
+		return diagsErrFromErr("creating hostname succeeded, loading free ssl certificate failed", err)
+	}
+
+	return nil
+}
+
+func loadFreeCertRetry(ctx context.Context, clt *client, timeout time.Duration, pullZoneID int64, hostname string) error {
 	const (
 		stateWaitingForDNSRecord = "waiting_for_dns_record"
 		stateDone                = "certificate_loaded"
@@ -173,7 +229,10 @@ func loadFreeCertRetry(ctx context.Context, clt *bunny.Client, timeout time.Dura
 		Timeout:    timeout,
 		MinTimeout: loadFreeCertMinDelay,
 		Refresh: func() (interface{}, string, error) {
+			pullZoneMu.Lock(pullZoneID)
 			err := clt.PullZone.LoadFreeCertificate(ctx, hostname)
+			clt.pullZoneCache.Invalidate(pullZoneID)
+			pullZoneMu.Unlock(pullZoneID)
 			if err != nil {
 				if apiErr, ok := err.(*bunny.APIError); ok {
 					if strings.Contains(strings.ToLower(apiErr.Message), "is not pointing to our servers") {
@@ -197,10 +256,10 @@ func loadFreeCertRetry(ctx context.Context, clt *bunny.Client, timeout time.Dura
 	return err
 }
 
-func resourceHostnameGetByName(ctx context.Context, clt *bunny.Client, pullZoneID int64, hostname string) (*bunny.Hostname, error) {
-	pz, err := clt.PullZone.Get(ctx, pullZoneID)
+func resourceHostnameGetByName(ctx context.Context, clt *client, pullZoneID int64, hostname string) (*bunny.PullZone, *bunny.Hostname, error) {
+	pz, err := clt.pullZoneCache.Get(ctx, pullZoneID)
 	if err != nil {
-		return nil, fmt.Errorf("retrieving pull zone failed: %w", err)
+		return nil, nil, fmt.Errorf("retrieving pull zone failed: %w", err)
 	}
 
 	for _, pzHostname := range pz.Hostnames {
@@ -211,14 +270,14 @@ func resourceHostnameGetByName(ctx context.Context, clt *bunny.Client, pullZoneI
 
 		if *pzHostname.Value == hostname {
 			if pzHostname.ID == nil {
-				return nil, fmt.Errorf("found hostname with name %q of pull zone (%d) but id is nil", hostname, pullZoneID)
+				return nil, nil, fmt.Errorf("found hostname with name %q of pull zone (%d) but id is nil", hostname, pullZoneID)
 			}
 
-			return pzHostname, nil
+			return pz, pzHostname, nil
 		}
 	}
 
-	return nil, errors.New("hostname not found")
+	return nil, nil, fmt.Errorf("hostname %q: %w", hostname, errNotFound)
 }
 
 func resourceDataToAddCustomHostnameOption(d *schema.ResourceData) *bunny.AddCustomHostnameOptions {
@@ -229,11 +288,15 @@ func resourceDataToAddCustomHostnameOption(d *schema.ResourceData) *bunny.AddCus
 }
 
 func resourceHostnameDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	pullZoneID := int64(d.Get(keyHostnamePullZoneID).(int))
 	hostnameOpt := hostnameFromResource(d)
 
+	pullZoneMu.Lock(pullZoneID)
+	defer pullZoneMu.Unlock(pullZoneID)
+	defer clt.pullZoneCache.Invalidate(pullZoneID)
+
 	return diag.FromErr(clt.PullZone.RemoveCustomHostname(ctx, pullZoneID, hostnameOpt))
 }
 
@@ -245,7 +308,7 @@ func hostnameFromResource(d *schema.ResourceData) *bunny.RemoveCustomHostnameOpt
 }
 
 func resourceHostnameRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	hostnameID, err := getIDAsInt64(d)
 	if err != nil {
@@ -254,22 +317,26 @@ func resourceHostnameRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	pullZoneID := int64(d.Get(keyHostnamePullZoneID).(int))
 
-	hostname, err := resourceHostnameGetByID(ctx, clt, pullZoneID, hostnameID)
+	pz, hostname, err := resourceHostnameGetByID(ctx, clt, pullZoneID, hostnameID)
 	if err != nil {
+		if isNotFoundErr(err) {
+			return removeFromState(d, "hostname", err)
+		}
+
 		return diagsErrFromErr("could not fetch hostname from provider", err)
 	}
 
-	if err := hostnameToResource(hostname, d); err != nil {
+	if err := hostnameToResource(pz, hostname, d); err != nil {
 		return diagsErrFromErr("converting api hostname to resource data failed", err)
 	}
 
 	return nil
 }
 
-func resourceHostnameGetByID(ctx context.Context, clt *bunny.Client, pullZoneID, hostnameID int64) (*bunny.Hostname, error) {
-	pz, err := clt.PullZone.Get(ctx, pullZoneID)
+func resourceHostnameGetByID(ctx context.Context, clt *client, pullZoneID, hostnameID int64) (*bunny.PullZone, *bunny.Hostname, error) {
+	pz, err := clt.pullZoneCache.Get(ctx, pullZoneID)
 	if err != nil {
-		return nil, fmt.Errorf("retrieving pull zone failed: %w", err)
+		return nil, nil, fmt.Errorf("retrieving pull zone failed: %w", err)
 	}
 
 	for _, hostname := range pz.Hostnames {
@@ -279,11 +346,11 @@ func resourceHostnameGetByID(ctx context.Context, clt *bunny.Client, pullZoneID,
 		}
 
 		if *hostname.ID == hostnameID {
-			return hostname, nil
+			return pz, hostname, nil
 		}
 	}
 
-	return nil, fmt.Errorf("pull zone with id %d, has no hostname with id: %d", pullZoneID, hostnameID)
+	return nil, nil, fmt.Errorf("pull zone with id %d, has no hostname with id %d: %w", pullZoneID, hostnameID, errNotFound)
 }
 
 func resourceHostnameImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -318,28 +385,44 @@ func resourceHostnameImport(ctx context.Context, d *schema.ResourceData, meta in
 	return []*schema.ResourceData{d}, nil
 }
 
-func hostnameToResource(hostname *bunny.Hostname, d *schema.ResourceData) error {
+func hostnameToResource(pz *bunny.PullZone, hostname *bunny.Hostname, d *schema.ResourceData) error {
 	if hostname.ID == nil {
 		return errors.New("id is empty")
 	}
 
 	d.SetId(strconv.FormatInt(*hostname.ID, 10))
 
-	if err := d.Set(keyHostnameHostname, hostname.Value); err != nil {
-		return err
+	for k, v := range hostnameFlatten(pz, hostname) {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
 	}
-	logger.Debugf("hostnameToResource %d, forcessl: %v", *hostname.ID, *hostname.ForceSSL)
-	if err := d.Set(keyHostnameForceSSL, hostname.ForceSSL); err != nil {
-		return err
+
+	return nil
+}
+
+// hostnameFlatten converts hostname of the pull zone pz to a map with the
+// computed attributes of the bunny_hostname resource.
+func hostnameFlatten(pz *bunny.PullZone, hostname *bunny.Hostname) map[string]interface{} {
+	return map[string]interface{}{
+		keyHostnameHostname:          hostname.Value,
+		keyHostnameForceSSL:          hostname.ForceSSL,
+		keyHostnameIsSystemHostname:  hostname.IsSystemHostname,
+		keyHostnameHasCertificate:    hostname.HasCertificate,
+		keyHostnameCertificateStatus: hostnameCertificateStatus(pz, hostname),
 	}
-	if err := d.Set(keyHostnameIsSystemHostname, hostname.IsSystemHostname); err != nil {
-		return err
+}
+
+func hostnameCertificateStatus(pz *bunny.PullZone, hostname *bunny.Hostname) string {
+	if ptr.GetBool(hostname.HasCertificate) {
+		return certificateStatusActive
 	}
-	if err := d.Set(keyHostnameHasCertificate, hostname.HasCertificate); err != nil {
-		return err
+
+	if ptr.GetBool(pz.EnableAutoSSL) {
+		return certificateStatusPending
 	}
 
-	return nil
+	return certificateStatusNone
 }
 
 func resourceHostnameUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -348,16 +431,20 @@ func resourceHostnameUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		return nil
 	}
 
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	pullZoneID := int64(d.Get(keyHostnamePullZoneID).(int))
 	hostname := d.Get(keyHostnameHostname).(string)
 	forceSSL := d.Get(keyHostnameForceSSL).(bool)
 
+	pullZoneMu.Lock(pullZoneID)
+	defer pullZoneMu.Unlock(pullZoneID)
+
 	err := clt.PullZone.SetForceSSL(ctx, pullZoneID, &bunny.SetForceSSLOptions{
 		Hostname: &hostname,
 		ForceSSL: &forceSSL,
 	})
+	clt.pullZoneCache.Invalidate(pullZoneID)
 
 	if err != nil {
 		return diagsErrFromErr("setting force ssl failed", err)
@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	bunny "github.com/simplesurance/bunny-go"
@@ -88,7 +90,7 @@ resource "bunny_hostname" "h1" {
 	hostname = "google.de"
 }`, pzName)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -153,7 +155,7 @@ resource "bunny_pullzone" "pz" {
 	origin_url ="https://bunny.net"
 }`, pzName)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -280,7 +282,7 @@ resource "bunny_hostname" "h2" {
 }
 `, pzName)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -306,7 +308,7 @@ resource "bunny_hostname" "h1" {
 }
 `, pzName, defPullZoneHostname(pzName))
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -338,7 +340,7 @@ resource "bunny_hostname" "h1" {
 }
 `, pzName)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -371,7 +373,7 @@ resource "bunny_hostname" "h1" {
 }
 `, pzName)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -387,7 +389,7 @@ func TestAccCertificates(t *testing.T) {
 	pzName := randResourceName()
 	hostname := randHostname()
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -515,7 +517,7 @@ func TestAccHostname_StateIsValidWhenCertUploadFails(t *testing.T) {
 	// a lot of bogus data.
 	var bogusCertData [800 * 1024]byte
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -559,3 +561,99 @@ resource "bunny_hostname" "h1" {
 		},
 	})
 }
+
+func TestFakeAPIHostname_createReadDelete(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	pzID := newFakeAPIPullZone(t, meta)
+	hostname := randHostname()
+
+	d := schema.TestResourceDataRaw(t, resourceHostname().Schema, map[string]interface{}{
+		keyHostnamePullZoneID:          int(pzID),
+		keyHostnameHostname:            hostname,
+		keyHostnameLoadFreeCertificate: true,
+		keyHostnameForceSSL:            true,
+	})
+
+	if diags := resourceHostnameCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	if !d.Get(keyHostnameHasCertificate).(bool) {
+		t.Errorf("expected %s to be true after loading free certificate", keyHostnameHasCertificate)
+	}
+
+	if status := d.Get(keyHostnameCertificateStatus).(string); status != certificateStatusActive {
+		t.Errorf("%s is %q, expected %q", keyHostnameCertificateStatus, status, certificateStatusActive)
+	}
+
+	if !d.Get(keyHostnameForceSSL).(bool) {
+		t.Errorf("expected %s to be true", keyHostnameForceSSL)
+	}
+
+	if diags := resourceHostnameRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read failed: %+v", diags)
+	}
+
+	pz, _ := srv.PullZone(pzID)
+	if len(pz.Hostnames) != 2 || ptr.GetString(pz.Hostnames[1].Value) != hostname {
+		t.Fatalf("expected pull zone to have hostname %q", hostname)
+	}
+
+	if diags := resourceHostnameDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete failed: %+v", diags)
+	}
+
+	pz, _ = srv.PullZone(pzID)
+	if len(pz.Hostnames) != 1 {
+		t.Errorf("hostname still exists after deletion")
+	}
+}
+
+func TestFakeAPIHostname_autoSSLSkipsLoadingFreeCertificate(t *testing.T) {
+	ctx := context.Background()
+	_, meta := newFakeAPIProvider(t)
+	clt := meta.(*client)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	_, err := clt.PullZone.Update(ctx, pzID, &bunny.PullZoneUpdateOptions{EnableAutoSSL: ptr.ToBool(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHostname().Schema, map[string]interface{}{
+		keyHostnamePullZoneID:          int(pzID),
+		keyHostnameHostname:            randHostname(),
+		keyHostnameLoadFreeCertificate: true,
+	})
+
+	diags := resourceHostnameCreate(ctx, d, meta)
+	if diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning that %s is ignored, got: %+v", keyHostnameLoadFreeCertificate, diags)
+	}
+
+	if d.Get(keyHostnameHasCertificate).(bool) {
+		t.Errorf("%s is true, expected that no certificate was loaded", keyHostnameHasCertificate)
+	}
+
+	if status := d.Get(keyHostnameCertificateStatus).(string); status != certificateStatusPending {
+		t.Errorf("%s is %q, expected %q", keyHostnameCertificateStatus, status, certificateStatusPending)
+	}
+}
+
+func TestFakeAPIHostname_readRemovesDeletedHostname(t *testing.T) {
+	_, meta := newFakeAPIProvider(t)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	d := schema.TestResourceDataRaw(t, resourceHostname().Schema, map[string]interface{}{
+		keyHostnamePullZoneID: int(pzID),
+		keyHostnameHostname:   randHostname(),
+	})
+	d.SetId("12345")
+
+	assertRemovedFromState(t, d, resourceHostnameRead(context.Background(), d, meta))
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	ptr "github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	bunny "github.com/simplesurance/bunny-go"
@@ -18,6 +21,7 @@ const (
 	keyAWSSigningRegionName              = "aws_signing_region_name"
 	keyAWSSigningSecret                  = "aws_signing_secret"
 	keyAllowedReferrers                  = "allowed_referrers"
+	keyAutoSSL                           = "auto_ssl"
 	keyBlockPostRequests                 = "block_post_requests"
 	keyBlockRootPathAccess               = "block_root_path_access"
 	keyBlockedCountries                  = "blocked_countries"
@@ -27,22 +31,16 @@ const (
 	keyCacheControlMaxAgeOverride        = "cache_control_max_age_override"
 	keyCacheErrorResponses               = "cache_error_responses"
 	keyDisableCookies                    = "disable_cookies"
-	keyEnableAvifVary                    = "enable_avif_vary"
 	keyEnableCacheSlice                  = "enable_cache_slice"
-	keyEnableCountryCodeVary             = "enable_country_code_vary"
 	keyEnableGeoZoneAF                   = "enable_geo_zone_af"
 	keyEnableGeoZoneAsia                 = "enable_geo_zone_asia"
 	keyEnableGeoZoneEU                   = "enable_geo_zone_eu"
 	keyEnableGeoZoneSA                   = "enable_geo_zone_sa"
 	keyEnableGeoZoneUS                   = "enable_geo_zone_us"
-	keyEnableHostnameVary                = "enable_hostname_vary"
 	keyCnameDomain                       = "cname_domain"
 	keyEnableLogging                     = "enable_logging"
-	keyEnableMobileVary                  = "enable_mobile_vary"
-	keyEnableOriginShield                = "enable_origin_shield"
 	keyEnableTLS1                        = "enable_tlsv1"
 	keyEnableTLS11                       = "enable_tls1_1"
-	keyEnableWebPVary                    = "enable_webp_vary"
 	keyErrorPageCustomCode               = "error_page_custom_code"
 	keyErrorPageEnableCustomCode         = "error_page_enable_custom_code"
 	keyErrorPageEnableStatuspageWidget   = "error_page_enable_statuspage_widget"
@@ -51,14 +49,17 @@ const (
 	keyFollowRedirects                   = "follow_redirects"
 	keyVideoLibraryID                    = "video_library_id"
 	keyIgnoreQueryStrings                = "ignore_query_strings"
+	keyLogAnonymizationType              = "log_anonymization_type"
+	keyLogFormat                         = "log_format"
 	keyLogForwardingEnabled              = "log_forwarding_enabled"
+	keyLogForwardingFormat               = "log_forwarding_format"
 	keyLogForwardingHostname             = "log_forwarding_hostname"
 	keyLogForwardingPort                 = "log_forwarding_port"
+	keyLogForwardingProtocol             = "log_forwarding_protocol"
 	keyLogForwardingToken                = "log_forwarding_token"
 	keyLoggingIPAnonymizationEnabled     = "logging_ip_anonymization_enabled"
 	keyLoggingSaveToStorage              = "logging_save_to_storage"
 	keyLoggingStorageZoneID              = "logging_storage_zone_id"
-	keyOriginShieldZoneCode              = "origin_shield_zone_code"
 	keyOriginURL                         = "origin_url"
 	keyEnabled                           = "enabled"
 	keyPermaCacheStorageZoneID           = "perma_cache_storage_zone_id"
@@ -78,8 +79,23 @@ const (
 	keyHeaders   = "headers"
 	keyLimits    = "limits"
 	keyOptimizer = "optimizer"
+	keyCache     = "cache"
+
+	keyOriginShield = "origin_shield"
+	keyVaryCache    = "vary_cache"
+
+	keyDDoSProtection = "ddos_protection"
+
+	keyOrigin = "origin"
 )
 
+// pullZoneMu serializes operations that modify a pull zone or one of its
+// edge rules, hostnames or certificates. The bunny API endpoints are not
+// concurrency safe, if the same pull zone is modified in parallel it might
+// happen that changes get lost.
+// Operations on different pull zones can run in parallel.
+var pullZoneMu = newKeyedMutex()
+
 func resourcePullZone() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePullZoneCreate,
@@ -89,6 +105,24 @@ func resourcePullZone() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: defaultResourceTimeouts(),
+
+		CustomizeDiff: customdiff.All(
+			validatePullZoneVaryCache,
+			validatePullZoneLogForwarding,
+			validatePullZoneOrigin,
+			validatePullZoneGeoZones,
+			computePullZoneOrigin,
+		),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourcePullZoneV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourcePullZoneStateUpgradeV0,
+			},
+		},
 
 		Schema: map[string]*schema.Schema{
 			keyAWSSigningEnabled: {
@@ -117,6 +151,12 @@ func resourcePullZone() *schema.Resource {
 				Description: "Sets the list of referrer hostnames that are allowed to access the Pull Zone. Requests containing the header Referer: hostname that is not on the list will be rejected. If empty, all the referrers are allowed.",
 				Optional:    true,
 			},
+			keyAutoSSL: {
+				Type:        schema.TypeBool,
+				Description: "Determines if free SSL certificates are issued and renewed automatically for all hostnames of the Pull Zone.",
+				Default:     false,
+				Optional:    true,
+			},
 			keyBlockPostRequests: {
 				Type:     schema.TypeBool,
 				Default:  false,
@@ -142,7 +182,7 @@ func resourcePullZone() *schema.Resource {
 			},
 			keyBudgetRedirectedCountries: {
 				Type:        schema.TypeSet,
-				Description: "Sets the list of two letter Alpha2 country codes that will be redirected to the cheapest possible region.",
+				Description: "Sets the list of two letter Alpha2 country codes that will be redirected to the cheapest possible region. Countries can not be redirected and blocked.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 			},
@@ -170,55 +210,42 @@ func resourcePullZone() *schema.Resource {
 				Optional:    true,
 				Default:     true,
 			},
-			keyEnableAvifVary: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the AVIF Vary feature should be enabled..",
-				Default:     false,
-				Optional:    true,
-			},
 			keyEnableCacheSlice: {
 				Type:        schema.TypeBool,
 				Description: "Determines if cache slicing (Optimize for video) should be enabled for this zone.",
 				Default:     false,
 				Optional:    true,
 			},
-			keyEnableCountryCodeVary: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the Country Code Vary feature should be enabled.",
-				Default:     false,
-				Optional:    true,
-			},
 			keyEnableGeoZoneAF: {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
-				Description: "Serve data from the Middle East & Africa Zone.",
+				Description: "Serve data from the Middle East & Africa Zone. Can not be enabled for Volume tier Pull Zones.",
 			},
 			keyEnableGeoZoneAsia: {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
 				Description: "Serve data from the Asia & Oceania Zone.",
 			},
 			keyEnableGeoZoneEU: {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
 				Description: "Serve data from the Europe Zone.",
 			},
 			keyEnableGeoZoneSA: {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
 				Description: "Serve data from the South America Zone.",
 			},
 			keyEnableGeoZoneUS: {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
 				Description: "Serve data from the US Zone.",
 			},
-			keyEnableHostnameVary: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the Hostname Vary feature should be enabled.",
-				Default:     false,
-				Optional:    true,
-			},
 			keyCnameDomain: {
 				Type:        schema.TypeString,
 				Description: "The CNAME domain of the Pull Zone for setting up custom hostnames.",
@@ -231,18 +258,6 @@ func resourcePullZone() *schema.Resource {
 				Default:     true,
 				Optional:    true,
 			},
-			keyEnableMobileVary: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the Mobile Vary feature is enabled.",
-				Default:     false,
-				Optional:    true,
-			},
-			keyEnableOriginShield: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the origin shield should be enabled.",
-				Default:     false,
-				Optional:    true,
-			},
 			keyEnableTLS1: {
 				Type:        schema.TypeBool,
 				Description: "Determines if the TLS 1 should be enabled on this zone.",
@@ -255,12 +270,6 @@ func resourcePullZone() *schema.Resource {
 				Default:     true,
 				Optional:    true,
 			},
-			keyEnableWebPVary: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the WebP Vary feature should be enabled.",
-				Default:     false,
-				Optional:    true,
-			},
 			keyErrorPageCustomCode: {
 				Type:        schema.TypeString,
 				Description: "Contains the custom error page code that will be returned",
@@ -306,11 +315,41 @@ func resourcePullZone() *schema.Resource {
 				Default:     true,
 				Optional:    true,
 			},
+			keyLogAnonymizationType: {
+				Type: schema.TypeString,
+				Description: "Determines how IP addresses are anonymized in the logs.\nValid values: " +
+					strings.Join(logAnonymizationTypeKeys, ", "),
+				Default:  "one_digit",
+				Optional: true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(logAnonymizationTypeKeys, false),
+				),
+			},
+			keyLogFormat: {
+				Type: schema.TypeString,
+				Description: "The format of the logs.\nValid values: " +
+					strings.Join(logFormatKeys, ", "),
+				Default:  "plain",
+				Optional: true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(logFormatKeys, false),
+				),
+			},
 			keyLogForwardingEnabled: {
 				Type:     schema.TypeBool,
 				Default:  false,
 				Optional: true,
 			},
+			keyLogForwardingFormat: {
+				Type: schema.TypeString,
+				Description: "The format of the forwarded logs.\nValid values: " +
+					strings.Join(logFormatKeys, ", "),
+				Default:  "plain",
+				Optional: true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(logFormatKeys, false),
+				),
+			},
 			keyLogForwardingHostname: {
 				Type:        schema.TypeString,
 				Description: "Sets the log forwarding destination hostname for the zone.",
@@ -323,6 +362,16 @@ func resourcePullZone() *schema.Resource {
 				Optional:         true,
 				ValidateDiagFunc: validateIsInt32,
 			},
+			keyLogForwardingProtocol: {
+				Type: schema.TypeString,
+				Description: "The protocol that is used to forward the logs.\nValid values: " +
+					strings.Join(logForwardingProtocolKeys, ", "),
+				Default:  "udp",
+				Optional: true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(logForwardingProtocolKeys, false),
+				),
+			},
 			keyLogForwardingToken: {
 				Type:        schema.TypeString,
 				Description: "Sets the log forwarding token for the zone.",
@@ -348,20 +397,10 @@ func resourcePullZone() *schema.Resource {
 				Default:     0,
 				Optional:    true,
 			},
-			keyOriginShieldZoneCode: {
+			keyOriginURL: {
 				Type:        schema.TypeString,
-				Description: "Determines the zone code where the origin shield should be set up.",
 				Optional:    true,
-				Default:     "FR",
-				ValidateDiagFunc: validation.ToDiagFunc(
-					validation.StringInSlice([]string{"FR", "IL"}, false),
-				),
-			},
-			keyOriginURL: {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The origin URL of the Pull Zone where the files are fetched from.",
-				ExactlyOneOf: []string{keyStorageZoneID},
+				Description: "The origin URL of the Pull Zone where the files are fetched from.",
 			},
 			keyPermaCacheStorageZoneID: {
 				Type:        schema.TypeInt,
@@ -390,6 +429,45 @@ func resourcePullZone() *schema.Resource {
 				Elem:             resourcePullZoneLimits,
 				DiffSuppressFunc: diffSupressMissingOptionalBlock,
 			},
+			keyCache: {
+				Type:             schema.TypeList,
+				MaxItems:         1,
+				Optional:         true,
+				Elem:             resourcePullZoneCache,
+				DiffSuppressFunc: diffSupressMissingOptionalBlock,
+			},
+			keyOriginShield: {
+				Type:             schema.TypeList,
+				MaxItems:         1,
+				Optional:         true,
+				Elem:             resourcePullZoneOriginShield,
+				DiffSuppressFunc: diffSupressMissingOptionalBlock,
+			},
+			keyVaryCache: {
+				Type:             schema.TypeList,
+				MaxItems:         1,
+				Optional:         true,
+				Elem:             resourcePullZoneVaryCache,
+				DiffSuppressFunc: diffSupressMissingOptionalBlock,
+			},
+			keyDDoSProtection: {
+				Type:             schema.TypeList,
+				MaxItems:         1,
+				Optional:         true,
+				Elem:             resourcePullZoneDDoSProtection,
+				DiffSuppressFunc: diffSupressMissingOptionalBlock,
+			},
+			keyOrigin: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"Configures the origin explicitly. If it is not defined, the origin type is derived from %s and %s and the block contains the origin of the Pull Zone.",
+					keyOriginURL, keyStorageZoneID,
+				),
+				Elem: resourcePullZoneOrigin,
+			},
 			keyOptimizer: {
 				Type:             schema.TypeList,
 				MaxItems:         1,
@@ -421,8 +499,13 @@ func resourcePullZone() *schema.Resource {
 			},
 
 			keyEnabled: {
-				Type:     schema.TypeBool,
-				Computed: true,
+				Type: schema.TypeBool,
+				Description: fmt.Sprintf(
+					"Determines if the Pull Zone is enabled. Disabled Pull Zones do not serve any requests. bunny.net disables Pull Zones that exceed their %s.%s.",
+					keyLimits, keyLimitsMonthlyBandwidthLimit,
+				),
+				Optional: true,
+				Default:  true,
 			},
 			keyName: {
 				Type:        schema.TypeString,
@@ -433,7 +516,6 @@ func resourcePullZone() *schema.Resource {
 			keyStorageZoneID: {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "The ID of the storage zone that the Pull Zone is linked to.",
 			},
 			keyZoneSecurityKey: {
@@ -445,7 +527,6 @@ func resourcePullZone() *schema.Resource {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
-				Computed:    true, // must be updated via Add/Remove Blocked Referrer API Endpoint, not implemented
 				Description: "The list of hostnames that will be blocked from accessing the Pull Zone.",
 			},
 
@@ -458,12 +539,24 @@ func resourcePullZone() *schema.Resource {
 }
 
 func resourcePullZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
+
+	originType, err := originTypeFromResource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var edgeScriptID *int64
+	if originType == bunny.OriginTypeEdgeScript {
+		edgeScriptID = structureFromResource(d, keyOrigin).getInt64Ptr(keyOriginScriptID)
+	}
 
 	pz, err := clt.PullZone.Add(ctx, &bunny.PullZoneAddOptions{
 		Name:          d.Get(keyName).(string),
 		OriginURL:     d.Get(keyOriginURL).(string),
 		StorageZoneID: getInt64Ptr(d, keyStorageZoneID),
+		OriginType:    &originType,
+		EdgeScriptID:  edgeScriptID,
 		Type:          d.Get(keyType).(int),
 	})
 	if err != nil {
@@ -500,7 +593,7 @@ func resourcePullZoneCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourcePullZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	pullZone, err := pullZoneFromResource(d)
 	if err != nil {
@@ -512,11 +605,36 @@ func resourcePullZoneUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
+	pullZoneMu.Lock(id)
+	defer pullZoneMu.Unlock(id)
+
 	updatedPullZone, err := clt.PullZone.Update(ctx, id, pullZone)
+	clt.pullZoneCache.Invalidate(id)
 	if err != nil {
 		return diagsErrFromErr("updating pull zone via API failed", err)
 	}
 
+	// blocked referrers can not be set via the Update endpoint, they are
+	// applied after the update succeeded
+	if d.HasChange(keyBlockedReferrers) {
+		err := pullZoneUpdateBlockedReferrers(ctx, clt, id, d)
+		clt.pullZoneCache.Invalidate(id)
+		if err != nil {
+			diags := diagsErrFromErr("updating blocked referrers via API failed", err)
+
+			// store the result of the successful update, the
+			// blocked referrers are applied again with the next
+			// update
+			if err := pullZoneToResource(updatedPullZone, d); err != nil {
+				diags = append(diags, diagsErrFromErr("converting api type to resource data after successful update failed", err)...)
+			}
+
+			return diags
+		}
+
+		updatedPullZone.BlockedReferrers = strSetAsSlice(d.Get(keyBlockedReferrers))
+	}
+
 	if err := pullZoneToResource(updatedPullZone, d); err != nil {
 		return diagsErrFromErr("converting api type to resource data after successful update failed", err)
 	}
@@ -531,16 +649,50 @@ func resourcePullZoneUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+// pullZoneUpdateBlockedReferrers adds and removes blocked referrers of the
+// pull zone, to match the ones defined in d.
+// Referrers are compared case-insensitively, changing only the case of a
+// referrer does not cause API calls.
+func pullZoneUpdateBlockedReferrers(ctx context.Context, clt *client, pullZoneID int64, d *schema.ResourceData) error {
+	o, n := d.GetChange(keyBlockedReferrers)
+	oldRefs := strSetAsSlice(o)
+	newRefs := strSetAsSlice(n)
+
+	for _, ref := range strSliceDifferenceFold(oldRefs, newRefs) {
+		err := clt.PullZone.RemoveBlockedReferrer(ctx, pullZoneID, &bunny.RemoveBlockedReferrerOptions{
+			Hostname: ptr.ToString(ref),
+		})
+		if err != nil {
+			return fmt.Errorf("removing blocked referrer %q failed: %w", ref, err)
+		}
+	}
+
+	for _, ref := range strSliceDifferenceFold(newRefs, oldRefs) {
+		err := clt.PullZone.AddBlockedReferrer(ctx, pullZoneID, &bunny.AddBlockedReferrerOptions{
+			Hostname: ptr.ToString(ref),
+		})
+		if err != nil {
+			return fmt.Errorf("adding blocked referrer %q failed: %w", ref, err)
+		}
+	}
+
+	return nil
+}
+
 func resourcePullZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	id, err := getIDAsInt64(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	pz, err := clt.PullZone.Get(ctx, id)
+	pz, err := clt.pullZoneCache.Get(ctx, id)
 	if err != nil {
+		if isNotFoundErr(err) {
+			return removeFromState(d, "pull zone", err)
+		}
+
 		return diagsErrFromErr("could not retrieve pull zone", err)
 	}
 
@@ -552,14 +704,18 @@ func resourcePullZoneRead(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourcePullZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	id, err := getIDAsInt64(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	pullZoneMu.Lock(id)
+	defer pullZoneMu.Unlock(id)
+
 	err = clt.PullZone.Delete(ctx, id)
+	clt.pullZoneCache.Invalidate(id)
 	if err != nil {
 		return diagsErrFromErr("could not delete pull zone", err)
 	}
@@ -617,15 +773,12 @@ func pullZoneToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
 	if err := d.Set(keyDisableCookies, pz.DisableCookies); err != nil {
 		return err
 	}
-	if err := d.Set(keyEnableAvifVary, pz.EnableAvifVary); err != nil {
+	if err := d.Set(keyAutoSSL, pz.EnableAutoSSL); err != nil {
 		return err
 	}
 	if err := d.Set(keyEnableCacheSlice, pz.EnableCacheSlice); err != nil {
 		return err
 	}
-	if err := d.Set(keyEnableCountryCodeVary, pz.EnableCountryCodeVary); err != nil {
-		return err
-	}
 	if err := d.Set(keyEnableGeoZoneAF, pz.EnableGeoZoneAF); err != nil {
 		return err
 	}
@@ -641,30 +794,18 @@ func pullZoneToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
 	if err := d.Set(keyEnableGeoZoneUS, pz.EnableGeoZoneUS); err != nil {
 		return err
 	}
-	if err := d.Set(keyEnableHostnameVary, pz.EnableHostnameVary); err != nil {
-		return err
-	}
 	if err := d.Set(keyCnameDomain, pz.CnameDomain); err != nil {
 		return err
 	}
 	if err := d.Set(keyEnableLogging, pz.EnableLogging); err != nil {
 		return err
 	}
-	if err := d.Set(keyEnableMobileVary, pz.EnableMobileVary); err != nil {
-		return err
-	}
-	if err := d.Set(keyEnableOriginShield, pz.EnableOriginShield); err != nil {
-		return err
-	}
 	if err := d.Set(keyEnableTLS1, pz.EnableTLS1); err != nil {
 		return err
 	}
 	if err := d.Set(keyEnableTLS11, pz.EnableTLS11); err != nil {
 		return err
 	}
-	if err := d.Set(keyEnableWebPVary, pz.EnableWebPVary); err != nil {
-		return err
-	}
 	if err := d.Set(keyErrorPageCustomCode, pz.ErrorPageCustomCode); err != nil {
 		return err
 	}
@@ -713,9 +854,6 @@ func pullZoneToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
 	if err := d.Set(keyLoggingStorageZoneID, pz.LoggingStorageZoneID); err != nil {
 		return err
 	}
-	if err := d.Set(keyOriginShieldZoneCode, pz.OriginShieldZoneCode); err != nil {
-		return err
-	}
 	if err := d.Set(keyOriginURL, pz.OriginURL); err != nil {
 		return err
 	}
@@ -766,6 +904,30 @@ func pullZoneToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
 		return err
 	}
 
+	if err := cacheToResource(pz, d); err != nil {
+		return err
+	}
+
+	if err := originShieldToResource(pz, d); err != nil {
+		return err
+	}
+
+	if err := varyCacheToResource(pz, d); err != nil {
+		return err
+	}
+
+	if err := ddosProtectionToResource(pz, d); err != nil {
+		return err
+	}
+
+	if err := loggingToResource(pz, d); err != nil {
+		return err
+	}
+
+	if err := originToResource(pz, d); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -788,16 +950,12 @@ func pullZoneFromResource(d *schema.ResourceData) (*bunny.PullZoneUpdateOptions,
 	res.CacheControlMaxAgeOverride = getInt64Ptr(d, keyCacheControlMaxAgeOverride)
 	res.CacheErrorResponses = getBoolPtr(d, keyCacheErrorResponses)
 	res.DisableCookies = getBoolPtr(d, keyDisableCookies)
-	res.EnableAvifVary = getBoolPtr(d, keyEnableAvifVary)
+	res.EnableAutoSSL = getBoolPtr(d, keyAutoSSL)
 	res.EnableCacheSlice = getBoolPtr(d, keyEnableCacheSlice)
-	res.EnableCountryCodeVary = getBoolPtr(d, keyEnableCountryCodeVary)
-	res.EnableHostnameVary = getBoolPtr(d, keyEnableHostnameVary)
+	res.Enabled = getBoolPtr(d, keyEnabled)
 	res.EnableLogging = getBoolPtr(d, keyEnableLogging)
-	res.EnableMobileVary = getBoolPtr(d, keyEnableMobileVary)
-	res.EnableOriginShield = getBoolPtr(d, keyEnableOriginShield)
 	res.EnableTLS1 = getBoolPtr(d, keyEnableTLS1)
 	res.EnableTLS11 = getBoolPtr(d, keyEnableTLS11)
-	res.EnableWebPVary = getBoolPtr(d, keyEnableWebPVary)
 	res.ErrorPageCustomCode = getStrPtr(d, keyErrorPageCustomCode)
 	res.ErrorPageEnableCustomCode = getBoolPtr(d, keyErrorPageEnableCustomCode)
 	res.ErrorPageEnableStatuspageWidget = getBoolPtr(d, keyErrorPageEnableStatuspageWidget)
@@ -812,7 +970,6 @@ func pullZoneFromResource(d *schema.ResourceData) (*bunny.PullZoneUpdateOptions,
 	res.LoggingIPAnonymizationEnabled = getBoolPtr(d, keyLoggingIPAnonymizationEnabled)
 	res.LoggingSaveToStorage = getBoolPtr(d, keyLoggingSaveToStorage)
 	res.LoggingStorageZoneID = getInt64Ptr(d, keyLoggingStorageZoneID)
-	res.OriginShieldZoneCode = getStrPtr(d, keyOriginShieldZoneCode)
 	res.OriginURL = getStrPtr(d, keyOriginURL)
 	res.PermaCacheStorageZoneID = getInt64Ptr(d, keyPermaCacheStorageZoneID)
 	res.Type = getIntPtr(d, keyType)
@@ -824,6 +981,22 @@ func pullZoneFromResource(d *schema.ResourceData) (*bunny.PullZoneUpdateOptions,
 	headersFromResource(&res, d)
 	limitsFromResource(&res, d)
 	optimizerFromResource(&res, d)
+	cacheFromResource(&res, d)
+	geoZonesFromResource(&res, d)
+	originShieldFromResource(&res, d)
+	varyCacheFromResource(&res, d)
+
+	if err := ddosProtectionFromResource(&res, d); err != nil {
+		return nil, err
+	}
+
+	if err := loggingFromResource(&res, d); err != nil {
+		return nil, err
+	}
+
+	if err := originFromResource(&res, d); err != nil {
+		return nil, err
+	}
 
 	return &res, nil
 }
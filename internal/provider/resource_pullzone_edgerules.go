@@ -0,0 +1,481 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+const (
+	keyPullZoneEdgeRulesPullZoneID = "pull_zone_id"
+	keyPullZoneEdgeRulesEdgeRule   = "edgerule"
+)
+
+// resourcePullZoneEdgeRules manages the complete, ordered list of edge rules
+// of a pull zone. In contrast to bunny_edgerule, edge rules that are not
+// defined in the resource are deleted.
+func resourcePullZoneEdgeRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePullZoneEdgeRulesCreate,
+		ReadContext:   resourcePullZoneEdgeRulesRead,
+		UpdateContext: resourcePullZoneEdgeRulesUpdate,
+		DeleteContext: resourcePullZoneEdgeRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePullZoneEdgeRulesImport,
+		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: validatePullZoneEdgeRules,
+
+		Schema: map[string]*schema.Schema{
+			keyPullZoneEdgeRulesPullZoneID: {
+				Type:        schema.TypeInt,
+				Description: "The ID of the Pull Zone to that the Edge Rules belong.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			keyPullZoneEdgeRulesEdgeRule: {
+				Type: schema.TypeList,
+				Description: "The Edge Rules of the Pull Zone, in the order in that they are executed. " +
+					"Edge Rules of the Pull Zone that are not defined are deleted. " +
+					"Must not be used together with bunny_edgerule resources for the same Pull Zone.",
+				Optional: true,
+				Elem:     resourcePullZoneEdgeRulesEdgeRule(),
+			},
+		},
+	}
+}
+
+// resourcePullZoneEdgeRulesEdgeRule returns the schema of an edgerule element.
+// It has the same attributes as the bunny_edgerule resource, except the pull
+// zone ID. The action parameters are kept for action types without a typed
+// action block. The description is not used to identify the edge rule
+// and can be set freely, the GUID is exported instead.
+func resourcePullZoneEdgeRulesEdgeRule() *schema.Resource {
+	s := resourceEdgeRule().Schema
+
+	delete(s, keyEdgeRulePullZoneID)
+
+	s[keyEdgeRuleDescription] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The description of the Edge Rule.",
+		Optional:    true,
+	}
+	s[keyEdgeRuleGUID] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The GUID of the Edge Rule.",
+		Computed:    true,
+	}
+
+	return &schema.Resource{Schema: s}
+}
+
+// validatePullZoneEdgeRules validates the actions and trigger patterns of
+// the edgerule elements.
+func validatePullZoneEdgeRules(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for i, elem := range d.Get(keyPullZoneEdgeRulesEdgeRule).([]interface{}) {
+		prefix := fmt.Sprintf("%s.%d.", keyPullZoneEdgeRulesEdgeRule, i)
+
+		err := validateEdgeRuleAction(structureFromElem([]interface{}{elem}), d.NewValueKnown(prefix+keyEdgeRuleActionType))
+		if err != nil {
+			return fmt.Errorf("%s.%d: %w", keyPullZoneEdgeRulesEdgeRule, i, err)
+		}
+	}
+
+	return validateEdgeRuleListTriggerPatterns(d.GetRawConfig(), keyPullZoneEdgeRulesEdgeRule)
+}
+
+func resourcePullZoneEdgeRulesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	pullZoneID := int64(d.Get(keyPullZoneEdgeRulesPullZoneID).(int))
+	d.SetId(strconv.FormatInt(pullZoneID, 10))
+
+	return resourcePullZoneEdgeRulesUpdate(ctx, d, meta)
+}
+
+func resourcePullZoneEdgeRulesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*client)
+
+	pullZoneID := int64(d.Get(keyPullZoneEdgeRulesPullZoneID).(int))
+
+	prev, cur := d.GetChange(keyPullZoneEdgeRulesEdgeRule)
+
+	prevEdgeRules := make([]structure, 0, len(prev.([]interface{})))
+	for _, elem := range prev.([]interface{}) {
+		prevEdgeRules = append(prevEdgeRules, structureFromElem([]interface{}{elem}))
+	}
+
+	edgeRules := make([]structure, 0, len(cur.([]interface{})))
+	for _, elem := range cur.([]interface{}) {
+		edgeRules = append(edgeRules, structureFromElem([]interface{}{elem}))
+	}
+
+	if err := pullZoneEdgeRulesAssignGUIDs(prevEdgeRules, edgeRules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := pullZoneEdgeRulesApply(ctx, clt, pullZoneID, edgeRules); err != nil {
+		diags := diagsErrFromErr("applying edge rules failed", err)
+
+		if readDiags := resourcePullZoneEdgeRulesRead(ctx, d, meta); readDiags.HasError() {
+			return append(diags, readDiags...)
+		}
+
+		return diags
+	}
+
+	return resourcePullZoneEdgeRulesRead(ctx, d, meta)
+}
+
+// pullZoneEdgeRulesAssignGUIDs sets the GUIDs of edgeRules to the GUIDs of
+// the edge rules in prevEdgeRules with the same content.
+// The GUID is computed, terraform assigns the GUIDs of the previous state by
+// list position. Without matching by content, reordered edge rules would be
+// modified in-place to the edge rule that was previously at their position.
+// Edge rules without an equal previous edge rule get the GUID of the
+// unmatched previous edge rule at the same position.
+func pullZoneEdgeRulesAssignGUIDs(prevEdgeRules, edgeRules []structure) error {
+	prevKeys := make([]string, len(prevEdgeRules))
+	for i, m := range prevEdgeRules {
+		key, err := edgeRuleContentKey(m)
+		if err != nil {
+			return fmt.Errorf("previous %s.%d: %w", keyPullZoneEdgeRulesEdgeRule, i, err)
+		}
+
+		prevKeys[i] = key
+	}
+
+	used := make([]bool, len(prevEdgeRules))
+	matched := make([]bool, len(edgeRules))
+
+	for i, m := range edgeRules {
+		m[keyEdgeRuleGUID] = ""
+
+		key, err := edgeRuleContentKey(m)
+		if err != nil {
+			return fmt.Errorf("%s.%d: %w", keyPullZoneEdgeRulesEdgeRule, i, err)
+		}
+
+		for j, prevKey := range prevKeys {
+			if used[j] || prevKey != key || prevEdgeRules[j].getStr(keyEdgeRuleGUID) == "" {
+				continue
+			}
+
+			m[keyEdgeRuleGUID] = prevEdgeRules[j].getStr(keyEdgeRuleGUID)
+			used[j] = true
+			matched[i] = true
+
+			break
+		}
+	}
+
+	for i, m := range edgeRules {
+		if matched[i] || i >= len(prevEdgeRules) || used[i] {
+			continue
+		}
+
+		m[keyEdgeRuleGUID] = prevEdgeRules[i].getStr(keyEdgeRuleGUID)
+		used[i] = true
+	}
+
+	return nil
+}
+
+// edgeRuleContentKey returns a string that is equal for edge rules that
+// result in the same API request, independent of their GUID.
+func edgeRuleContentKey(m structure) (string, error) {
+	opts, err := edgeRuleExpand(nil, m)
+	if err != nil {
+		return "", err
+	}
+
+	buf, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+// pullZoneEdgeRulesApply changes the edge rules of the pull zone to
+// edgeRules.
+// Existing edge rules are identified by their GUID. Edge rules of the pull
+// zone that are not in edgeRules are deleted, edgeRules without a GUID or
+// with a GUID that does not exist anymore are created. Existing edge rules
+// are only updated if their settings or their position differ.
+// The pull zone is locked while the edge rules are applied, to ensure that
+// the read edge rules are not modified in parallel by other resources.
+func pullZoneEdgeRulesApply(ctx context.Context, clt *client, pullZoneID int64, edgeRules []structure) error {
+	pullZoneMu.Lock(pullZoneID)
+	defer pullZoneMu.Unlock(pullZoneID)
+	defer clt.pullZoneCache.Invalidate(pullZoneID)
+
+	clt.pullZoneCache.Invalidate(pullZoneID)
+	pz, err := clt.pullZoneCache.Get(ctx, pullZoneID)
+	if err != nil {
+		return fmt.Errorf("retrieving pull zone failed: %w", err)
+	}
+
+	configuredGUIDs := make(map[string]struct{}, len(edgeRules))
+	for _, m := range edgeRules {
+		if guid := m.getStr(keyEdgeRuleGUID); guid != "" {
+			configuredGUIDs[guid] = struct{}{}
+		}
+	}
+
+	existing := make(map[string]*bunny.EdgeRule, len(pz.EdgeRules))
+	for _, edgeRule := range pz.EdgeRules {
+		guid := ptr.GetString(edgeRule.GUID)
+		if guid == "" {
+			continue
+		}
+
+		if _, exists := configuredGUIDs[guid]; exists {
+			existing[guid] = edgeRule
+			continue
+		}
+
+		err := clt.PullZone.DeleteEdgeRule(ctx, pullZoneID, guid)
+		clt.pullZoneCache.Invalidate(pullZoneID)
+		if err != nil {
+			return fmt.Errorf("deleting edge rule %q failed: %w", guid, err)
+		}
+	}
+
+	for i, m := range edgeRules {
+		cur := existing[m.getStr(keyEdgeRuleGUID)]
+
+		var guid *string
+		if cur != nil {
+			guid = cur.GUID
+		}
+
+		opts, err := edgeRuleExpand(guid, m)
+		if err != nil {
+			return fmt.Errorf("%s.%d: %w", keyPullZoneEdgeRulesEdgeRule, i, err)
+		}
+
+		opts.OrderIndex = ptr.ToInt32(int32(i))
+
+		if cur == nil {
+			newGUID, err := pullZoneEdgeRuleCreate(ctx, clt, pullZoneID, opts)
+			if err != nil {
+				return fmt.Errorf("creating edge rule %s.%d failed: %w", keyPullZoneEdgeRulesEdgeRule, i, err)
+			}
+
+			m[keyEdgeRuleGUID] = newGUID
+			continue
+		}
+
+		if edgeRuleIsUpToDate(cur, opts) {
+			continue
+		}
+
+		err = clt.PullZone.AddOrUpdateEdgeRule(ctx, pullZoneID, opts)
+		clt.pullZoneCache.Invalidate(pullZoneID)
+		if err != nil {
+			return fmt.Errorf("updating edge rule %q failed: %w", *opts.GUID, err)
+		}
+	}
+
+	return nil
+}
+
+// pullZoneEdgeRuleCreate creates an edge rule and returns its GUID.
+// The API does not return the GUID of created edge rules, it is the GUID that
+// the pull zone has after the edge rule was created and did not have before.
+// The caller must hold the lock of the pull zone, otherwise edge rules that
+// are created in parallel can not be distinguished.
+func pullZoneEdgeRuleCreate(ctx context.Context, clt *client, pullZoneID int64, opts *bunny.AddOrUpdateEdgeRuleOptions) (string, error) {
+	pz, err := clt.pullZoneCache.Get(ctx, pullZoneID)
+	if err != nil {
+		return "", fmt.Errorf("retrieving pull zone failed: %w", err)
+	}
+
+	prevGUIDs := make(map[string]struct{}, len(pz.EdgeRules))
+	for _, edgeRule := range pz.EdgeRules {
+		prevGUIDs[ptr.GetString(edgeRule.GUID)] = struct{}{}
+	}
+
+	err = clt.PullZone.AddOrUpdateEdgeRule(ctx, pullZoneID, opts)
+	clt.pullZoneCache.Invalidate(pullZoneID)
+	if err != nil {
+		return "", err
+	}
+
+	pz, err = clt.pullZoneCache.Get(ctx, pullZoneID)
+	if err != nil {
+		return "", fmt.Errorf("edge rule created successfully, retrieving pull zone to look up its guid failed: %w", err)
+	}
+
+	var newGUIDs []string
+	for _, edgeRule := range pz.EdgeRules {
+		guid := ptr.GetString(edgeRule.GUID)
+		if _, exists := prevGUIDs[guid]; !exists && guid != "" {
+			newGUIDs = append(newGUIDs, guid)
+		}
+	}
+
+	if len(newGUIDs) != 1 {
+		return "", fmt.Errorf("edge rule created successfully, looking up its guid failed: pull zone has %d new edge rules, expected 1", len(newGUIDs))
+	}
+
+	return newGUIDs[0], nil
+}
+
+// edgeRuleIsUpToDate returns true if the existing edge rule cur has the same
+// settings and position as opts.
+// Unset fields are equal to their zero values, the order of triggers and
+// their patterns is ignored.
+func edgeRuleIsUpToDate(cur *bunny.EdgeRule, opts *bunny.AddOrUpdateEdgeRuleOptions) bool {
+	curOpts := bunny.AddOrUpdateEdgeRuleOptions(*cur)
+
+	return reflect.DeepEqual(edgeRuleNormalize(&curOpts), edgeRuleNormalize(opts))
+}
+
+// edgeRuleNormalize returns a copy of opts with all fields set and the
+// triggers and their patterns sorted.
+func edgeRuleNormalize(opts *bunny.AddOrUpdateEdgeRuleOptions) *bunny.AddOrUpdateEdgeRuleOptions {
+	triggers := make([]*bunny.EdgeRuleTrigger, 0, len(opts.Triggers))
+	triggerKeys := make(map[*bunny.EdgeRuleTrigger]string, len(opts.Triggers))
+
+	for _, trigger := range opts.Triggers {
+		patterns := make([]string, len(trigger.PatternMatches))
+		copy(patterns, trigger.PatternMatches)
+		sort.Strings(patterns)
+
+		t := bunny.EdgeRuleTrigger{
+			Type:                ptr.ToInt(ptr.GetInt(trigger.Type)),
+			PatternMatches:      patterns,
+			PatternMatchingType: ptr.ToInt(ptr.GetInt(trigger.PatternMatchingType)),
+			Parameter1:          ptr.ToString(ptr.GetString(trigger.Parameter1)),
+		}
+
+		triggers = append(triggers, &t)
+		triggerKeys[&t] = fmt.Sprintf("%d %d %q %q", *t.Type, *t.PatternMatchingType, *t.Parameter1, t.PatternMatches)
+	}
+
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggerKeys[triggers[i]] < triggerKeys[triggers[j]]
+	})
+
+	return &bunny.AddOrUpdateEdgeRuleOptions{
+		GUID:                ptr.ToString(ptr.GetString(opts.GUID)),
+		ActionType:          ptr.ToInt(ptr.GetInt(opts.ActionType)),
+		ActionParameter1:    ptr.ToString(ptr.GetString(opts.ActionParameter1)),
+		ActionParameter2:    ptr.ToString(ptr.GetString(opts.ActionParameter2)),
+		Triggers:            triggers,
+		TriggerMatchingType: ptr.ToInt(ptr.GetInt(opts.TriggerMatchingType)),
+		Description:         ptr.ToString(ptr.GetString(opts.Description)),
+		Enabled:             ptr.ToBool(ptr.GetBool(opts.Enabled)),
+		OrderIndex:          ptr.ToInt32(ptr.GetInt32(opts.OrderIndex)),
+	}
+}
+
+func resourcePullZoneEdgeRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*client)
+
+	pullZoneID := int64(d.Get(keyPullZoneEdgeRulesPullZoneID).(int))
+
+	pz, err := clt.pullZoneCache.Get(ctx, pullZoneID)
+	if err != nil {
+		if isNotFoundErr(err) {
+			return removeFromState(d, "edge rules", fmt.Errorf("retrieving pull zone failed: %w", err))
+		}
+
+		return diagsErrFromErr("retrieving pull zone failed", err)
+	}
+
+	edgeRules, err := pullZoneEdgeRulesFlatten(pz.EdgeRules, d.Get(keyPullZoneEdgeRulesEdgeRule).([]interface{}))
+	if err != nil {
+		return diagsErrFromErr("converting edge rules api type to terraform ResourceData failed", err)
+	}
+
+	if err := d.Set(keyPullZoneEdgeRulesEdgeRule, edgeRules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// pullZoneEdgeRulesFlatten converts edgeRules to a list of edgerule
+// elements, ordered by their execution order.
+// prev are the edgerule elements of the previous state, they determine if the
+// action of an edge rule is represented by its typed action block or by the
+// action parameters.
+func pullZoneEdgeRulesFlatten(edgeRules []*bunny.EdgeRule, prev []interface{}) ([]map[string]interface{}, error) {
+	sorted := make([]*bunny.EdgeRule, len(edgeRules))
+	copy(sorted, edgeRules)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return ptr.GetInt32(sorted[i].OrderIndex) < ptr.GetInt32(sorted[j].OrderIndex)
+	})
+
+	res := make([]map[string]interface{}, 0, len(sorted))
+	for i, edgeRule := range sorted {
+		m, err := edgeRuleFlatten(edgeRule)
+		if err != nil {
+			return nil, fmt.Errorf("edge rule %q: %w", ptr.GetString(edgeRule.GUID), err)
+		}
+
+		prevEdgeRule := structure{}
+		if i < len(prev) {
+			prevEdgeRule = structureFromElem(prev[i : i+1])
+		}
+
+		edgeRuleSelectActionRepresentation(m, prevEdgeRule)
+		m[keyEdgeRuleGUID] = edgeRule.GUID
+
+		res = append(res, m)
+	}
+
+	return res, nil
+}
+
+func resourcePullZoneEdgeRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*client)
+
+	pullZoneID := int64(d.Get(keyPullZoneEdgeRulesPullZoneID).(int))
+
+	pullZoneMu.Lock(pullZoneID)
+	defer pullZoneMu.Unlock(pullZoneID)
+	defer clt.pullZoneCache.Invalidate(pullZoneID)
+
+	for _, elem := range d.Get(keyPullZoneEdgeRulesEdgeRule).([]interface{}) {
+		guid := structureFromElem([]interface{}{elem}).getStr(keyEdgeRuleGUID)
+		if guid == "" {
+			continue
+		}
+
+		if err := clt.PullZone.DeleteEdgeRule(ctx, pullZoneID, guid); err != nil && !isNotFoundErr(err) {
+			return diagsErrFromErr(fmt.Sprintf("deleting edge rule %q failed", guid), err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePullZoneEdgeRulesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	pullZoneID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be the ID of the pull zone", d.Id())
+	}
+
+	if err := d.Set(keyPullZoneEdgeRulesPullZoneID, pullZoneID); err != nil {
+		return nil, err
+	}
+
+	if diags := resourcePullZoneEdgeRulesRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("reading edge rules failed: %+v", diags)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
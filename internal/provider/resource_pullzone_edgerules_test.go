@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	bunny "github.com/simplesurance/bunny-go"
+)
+
+func pullZoneEdgeRulesTestRule(actionType, description string) map[string]interface{} {
+	return map[string]interface{}{
+		keyEdgeRuleActionType:          actionType,
+		keyEdgeRuleDescription:         description,
+		keyEdgeRuleTriggerMatchingType: "all",
+		keyEdgeRuleTriggers: []interface{}{
+			map[string]interface{}{
+				keyEdgeRuleTriggerType:                "url",
+				keyEdgeRuleTriggerPatternMatchingType: "any",
+				keyEdgeRuleTriggerPatternMatches:      []interface{}{"*/" + description},
+			},
+		},
+	}
+}
+
+func TestFakeAPIPullZoneEdgeRules_createReorderDelete(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	clt := meta.(*client)
+	res := resourcePullZoneEdgeRules()
+	pzID := newFakeAPIPullZone(t, meta)
+
+	err := clt.PullZone.AddOrUpdateEdgeRule(ctx, pzID, &bunny.AddOrUpdateEdgeRuleOptions{
+		ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeBlockRequest),
+		TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+		Description:         ptr.ToString("created in the panel"),
+		Enabled:             ptr.ToBool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// assertEdgeRules ensures that the pull zone has edge rules with the
+	// passed descriptions in the same order and that the GUIDs in the state
+	// match them
+	assertEdgeRules := func(t *testing.T, d *schema.ResourceData, descriptions ...string) {
+		t.Helper()
+
+		pz, _ := srv.PullZone(pzID)
+		if len(pz.EdgeRules) != len(descriptions) {
+			t.Fatalf("pull zone has %d edge rules, expected %d: %+v", len(pz.EdgeRules), len(descriptions), pz.EdgeRules)
+		}
+
+		if cnt := d.Get(keyPullZoneEdgeRulesEdgeRule + ".#").(int); cnt != len(descriptions) {
+			t.Fatalf("state has %d edge rules, expected %d", cnt, len(descriptions))
+		}
+
+		for i, er := range pz.EdgeRules {
+			if desc := ptr.GetString(er.Description); desc != descriptions[i] {
+				t.Errorf("edge rule %d has description %q, expected %q", i, desc, descriptions[i])
+			}
+
+			key := keyPullZoneEdgeRulesEdgeRule + "." + strconv.Itoa(i) + "." + keyEdgeRuleGUID
+			if guid := d.Get(key).(string); guid != ptr.GetString(er.GUID) {
+				t.Errorf("%s is %q, expected %q", key, guid, ptr.GetString(er.GUID))
+			}
+		}
+	}
+
+	config := map[string]interface{}{
+		keyPullZoneEdgeRulesPullZoneID: int(pzID),
+		keyPullZoneEdgeRulesEdgeRule: []interface{}{
+			pullZoneEdgeRulesTestRule("force_ssl", "first"),
+			pullZoneEdgeRulesTestRule("block_request", "second"),
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	if diags := resourcePullZoneEdgeRulesCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	if d.Id() != strconv.FormatInt(pzID, 10) {
+		t.Errorf("id is %q, expected the pull zone id %d", d.Id(), pzID)
+	}
+
+	assertEdgeRules(t, d, "first", "second")
+
+	guids := map[string]string{}
+	pz, _ := srv.PullZone(pzID)
+	for _, er := range pz.EdgeRules {
+		guids[ptr.GetString(er.Description)] = ptr.GetString(er.GUID)
+	}
+
+	statusCodeRule := pullZoneEdgeRulesTestRule("", "third")
+	statusCodeRule["set_status_code"] = []interface{}{
+		map[string]interface{}{keyEdgeRuleActionStatusCode: 410},
+	}
+
+	config[keyPullZoneEdgeRulesEdgeRule] = []interface{}{
+		statusCodeRule,
+		pullZoneEdgeRulesTestRule("block_request", "second"),
+		pullZoneEdgeRulesTestRule("force_ssl", "first"),
+	}
+	d = testResourceDataUpdate(t, res, d, config, meta)
+	if diags := resourcePullZoneEdgeRulesUpdate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update failed: %+v", diags)
+	}
+
+	assertEdgeRules(t, d, "third", "second", "first")
+
+	pz, _ = srv.PullZone(pzID)
+	for _, er := range pz.EdgeRules {
+		desc := ptr.GetString(er.Description)
+		if guid, exists := guids[desc]; exists && guid != ptr.GetString(er.GUID) {
+			t.Errorf("GUID of edge rule %q changed from %q to %q after reordering", desc, guid, ptr.GetString(er.GUID))
+		}
+
+		for prevDesc, guid := range guids {
+			if prevDesc != desc && guid == ptr.GetString(er.GUID) {
+				t.Errorf("edge rule %q has the GUID %q of the previous edge rule %q", desc, guid, prevDesc)
+			}
+		}
+	}
+
+	if ptr.GetInt(pz.EdgeRules[0].ActionType) != bunny.EdgeRuleActionTypeSetStatusCode || ptr.GetString(pz.EdgeRules[0].ActionParameter1) != "410" {
+		t.Errorf("expected first edge rule to respond with status code 410, got: %+v", pz.EdgeRules[0])
+	}
+
+	if code := d.Get(keyPullZoneEdgeRulesEdgeRule + ".0.set_status_code.0." + keyEdgeRuleActionStatusCode).(int); code != 410 {
+		t.Errorf("status code of the first edge rule in the state is %d, expected 410", code)
+	}
+
+	if diags := resourcePullZoneEdgeRulesDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete failed: %+v", diags)
+	}
+
+	if pz, _ := srv.PullZone(pzID); len(pz.EdgeRules) != 0 {
+		t.Errorf("pull zone has %d edge rules after delete, expected 0", len(pz.EdgeRules))
+	}
+}
+
+func TestFakeAPIPullZoneEdgeRules_import(t *testing.T) {
+	ctx := context.Background()
+	_, meta := newFakeAPIProvider(t)
+	clt := meta.(*client)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	for _, desc := range []string{"a", "b"} {
+		err := clt.PullZone.AddOrUpdateEdgeRule(ctx, pzID, &bunny.AddOrUpdateEdgeRuleOptions{
+			ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeForceSSL),
+			TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+			Description:         ptr.ToString(desc),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcePullZoneEdgeRules().Schema, map[string]interface{}{})
+	d.SetId(strconv.FormatInt(pzID, 10))
+
+	if _, err := resourcePullZoneEdgeRulesImport(ctx, d, meta); err != nil {
+		t.Fatalf("import failed: %s", err)
+	}
+
+	if id := d.Get(keyPullZoneEdgeRulesPullZoneID).(int); int64(id) != pzID {
+		t.Errorf("%s is %d, expected %d", keyPullZoneEdgeRulesPullZoneID, id, pzID)
+	}
+
+	if desc := d.Get(keyPullZoneEdgeRulesEdgeRule + ".1." + keyEdgeRuleDescription).(string); desc != "b" {
+		t.Errorf("second edge rule has description %q, expected b", desc)
+	}
+}
+
+func TestFakeAPIPullZoneEdgeRules_readRemovesDeletedPullZone(t *testing.T) {
+	_, meta := newFakeAPIProvider(t)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	d := schema.TestResourceDataRaw(t, resourcePullZoneEdgeRules().Schema, map[string]interface{}{
+		keyPullZoneEdgeRulesPullZoneID: int(pzID) + 1000,
+	})
+	d.SetId(strconv.FormatInt(pzID+1000, 10))
+
+	assertRemovedFromState(t, d, resourcePullZoneEdgeRulesRead(context.Background(), d, meta))
+}
+
+func TestFakeAPIPullZoneEdgeRules_actionParametersWithoutBlock(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	clt := meta.(*client)
+	res := resourcePullZoneEdgeRules()
+	pzID := newFakeAPIPullZone(t, meta)
+
+	err := clt.PullZone.AddOrUpdateEdgeRule(ctx, pzID, &bunny.AddOrUpdateEdgeRuleOptions{
+		ActionType:       ptr.ToInt(bunny.EdgeRuleActionTypeSetNetworkRateLimit),
+		ActionParameter1: ptr.ToString("100"),
+		ActionParameter2: ptr.ToString("50"),
+		Triggers: []*bunny.EdgeRuleTrigger{
+			{
+				Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURL),
+				PatternMatches:      []string{"*"},
+				PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+			},
+		},
+		TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+		Description:         ptr.ToString("rate limit"),
+		Enabled:             ptr.ToBool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{})
+	d.SetId(strconv.FormatInt(pzID, 10))
+
+	if _, err := resourcePullZoneEdgeRulesImport(ctx, d, meta); err != nil {
+		t.Fatalf("import failed: %s", err)
+	}
+
+	// assertParameters ensures that the action parameters of the edge rule
+	// in the state and of the pull zone are unchanged
+	assertParameters := func(t *testing.T, d *schema.ResourceData) {
+		t.Helper()
+
+		for key, want := range map[string]string{keyEdgeRuleActionParameter1: "100", keyEdgeRuleActionParameter2: "50"} {
+			if v := d.Get(keyPullZoneEdgeRulesEdgeRule + ".0." + key).(string); v != want {
+				t.Errorf("%s of the edge rule in the state is %q, expected %q", key, v, want)
+			}
+		}
+
+		pz, _ := srv.PullZone(pzID)
+		if len(pz.EdgeRules) != 1 {
+			t.Fatalf("pull zone has %d edge rules, expected 1", len(pz.EdgeRules))
+		}
+
+		er := pz.EdgeRules[0]
+		if ptr.GetString(er.ActionParameter1) != "100" || ptr.GetString(er.ActionParameter2) != "50" {
+			t.Errorf("action parameters of the edge rule are %q and %q, expected 100 and 50",
+				ptr.GetString(er.ActionParameter1), ptr.GetString(er.ActionParameter2),
+			)
+		}
+	}
+
+	assertParameters(t, d)
+
+	// the configuration matches the imported edge rule, only the
+	// description is changed to ensure that it is applied
+	d = testResourceDataUpdate(t, res, d, map[string]interface{}{
+		keyPullZoneEdgeRulesPullZoneID: int(pzID),
+		keyPullZoneEdgeRulesEdgeRule: []interface{}{
+			map[string]interface{}{
+				keyEdgeRuleActionType:          "set_network_rate_limit",
+				keyEdgeRuleActionParameter1:    "100",
+				keyEdgeRuleActionParameter2:    "50",
+				keyEdgeRuleDescription:         "rate limited",
+				keyEdgeRuleTriggerMatchingType: "any",
+				keyEdgeRuleTriggers: []interface{}{
+					map[string]interface{}{
+						keyEdgeRuleTriggerType:                "url",
+						keyEdgeRuleTriggerPatternMatchingType: "any",
+						keyEdgeRuleTriggerPatternMatches:      []interface{}{"*"},
+					},
+				},
+			},
+		},
+	}, meta)
+	if diags := resourcePullZoneEdgeRulesUpdate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update failed: %+v", diags)
+	}
+
+	assertParameters(t, d)
+
+	if pz, _ := srv.PullZone(pzID); ptr.GetString(pz.EdgeRules[0].Description) != "rate limited" {
+		t.Errorf("description of the edge rule is %q, expected the updated description", ptr.GetString(pz.EdgeRules[0].Description))
+	}
+}
+
+func TestEdgeRuleIsUpToDate(t *testing.T) {
+	cur := &bunny.EdgeRule{
+		GUID:             ptr.ToString("guid"),
+		ActionType:       ptr.ToInt(bunny.EdgeRuleActionTypeSetStatusCode),
+		ActionParameter1: ptr.ToString("410"),
+		ActionParameter2: ptr.ToString(""),
+		Triggers: []*bunny.EdgeRuleTrigger{
+			{
+				Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURL),
+				PatternMatches:      []string{"*/a", "*/b"},
+				PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+				Parameter1:          ptr.ToString(""),
+			},
+			{
+				Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeRequestHeader),
+				PatternMatches:      []string{"x"},
+				PatternMatchingType: ptr.ToInt(bunny.MatchingTypeNone),
+				Parameter1:          ptr.ToString("X-Header"),
+			},
+		},
+		TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+		Description:         ptr.ToString(""),
+		Enabled:             ptr.ToBool(true),
+		OrderIndex:          ptr.ToInt32(1),
+	}
+
+	// opts returns the options that are equal to cur
+	opts := func() *bunny.AddOrUpdateEdgeRuleOptions {
+		return &bunny.AddOrUpdateEdgeRuleOptions{
+			GUID:             ptr.ToString("guid"),
+			ActionType:       ptr.ToInt(bunny.EdgeRuleActionTypeSetStatusCode),
+			ActionParameter1: ptr.ToString("410"),
+			Triggers: []*bunny.EdgeRuleTrigger{
+				{
+					Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeRequestHeader),
+					PatternMatches:      []string{"x"},
+					PatternMatchingType: ptr.ToInt(bunny.MatchingTypeNone),
+					Parameter1:          ptr.ToString("X-Header"),
+				},
+				{
+					Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURL),
+					PatternMatches:      []string{"*/b", "*/a"},
+					PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+					Parameter1:          ptr.ToString(""),
+				},
+			},
+			TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+			Enabled:             ptr.ToBool(true),
+			OrderIndex:          ptr.ToInt32(1),
+		}
+	}
+
+	testcases := []struct {
+		name     string
+		modify   func(*bunny.AddOrUpdateEdgeRuleOptions)
+		expected bool
+	}{
+		{
+			name:     "equal",
+			modify:   func(*bunny.AddOrUpdateEdgeRuleOptions) {},
+			expected: true,
+		},
+		{
+			name:   "order index differs",
+			modify: func(o *bunny.AddOrUpdateEdgeRuleOptions) { o.OrderIndex = ptr.ToInt32(0) },
+		},
+		{
+			name:   "action parameter differs",
+			modify: func(o *bunny.AddOrUpdateEdgeRuleOptions) { o.ActionParameter1 = ptr.ToString("404") },
+		},
+		{
+			name:   "description differs",
+			modify: func(o *bunny.AddOrUpdateEdgeRuleOptions) { o.Description = ptr.ToString("desc") },
+		},
+		{
+			name:   "trigger pattern differs",
+			modify: func(o *bunny.AddOrUpdateEdgeRuleOptions) { o.Triggers[1].PatternMatches = []string{"*/a"} },
+		},
+		{
+			name:   "trigger removed",
+			modify: func(o *bunny.AddOrUpdateEdgeRuleOptions) { o.Triggers = o.Triggers[:1] },
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := opts()
+			tc.modify(o)
+
+			if result := edgeRuleIsUpToDate(cur, o); result != tc.expected {
+				t.Errorf("edgeRuleIsUpToDate returned %t, expected %t", result, tc.expected)
+			}
+		})
+	}
+}
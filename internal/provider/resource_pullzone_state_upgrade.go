@@ -0,0 +1,480 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourcePullZoneV0 returns version 0 of the pull zone schema.
+// It is a snapshot of the schema that must not be modified when the current
+// schema changes, the attribute names are string literals for this reason.
+// Only the fields that define the type of the state are set.
+func resourcePullZoneV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"aws_signing_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"aws_signing_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"aws_signing_region_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"aws_signing_secret": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"allowed_referrers": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"block_post_requests": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"block_root_path_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"blocked_countries": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"blocked_ips": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"budget_redirected_countries": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cache_control_browser_max_age_override": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"cache_control_max_age_override": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"cache_error_responses": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"disable_cookies": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_avif_vary": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_cache_slice": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_country_code_vary": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_geo_zone_af": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_geo_zone_asia": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_geo_zone_eu": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_geo_zone_sa": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_geo_zone_us": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"enable_hostname_vary": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"cname_domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enable_logging": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_mobile_vary": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_origin_shield": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_tlsv1": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_tls1_1": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enable_webp_vary": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"error_page_custom_code": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"error_page_enable_custom_code": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"error_page_enable_statuspage_widget": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"error_page_statuspage_code": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"error_page_whitelabel": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"follow_redirects": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"video_library_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ignore_query_strings": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"log_forwarding_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"log_forwarding_hostname": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"log_forwarding_port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"log_forwarding_token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"logging_ip_anonymization_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"logging_save_to_storage": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"logging_storage_zone_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"origin_shield_zone_code": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"origin_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"perma_cache_storage_zone_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"safehop": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"origin_connect_timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"origin_response_timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"origin_retries": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"origin_retry_5xx_response": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"origin_retry_connection_timeout": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"origin_retry_delay": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"origin_retry_response_timeout": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"headers": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable_access_control_origin_header": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"access_control_origin_header_extensions": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"add_canonical_header": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"add_host_header": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"limits": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_limit_per_ip_count": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"request_limit": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"monthly_bandwidth_limit": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"optimizer": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"enable_webp": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"minify_css": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"minify_javascript": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"enable_manipulation_engine": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"smart_image_optimization": {
+							Type:     schema.TypeList,
+							MaxItems: 1,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enabled": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"desktop_max_width": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"image_quality": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"mobile_max_width": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"mobile_image_quality": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"watermark": {
+							Type:     schema.TypeList,
+							MaxItems: 1,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enabled": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"url": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"offset": {
+										Type:     schema.TypeFloat,
+										Optional: true,
+									},
+									"min_image_size": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"position": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"type": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"verify_origin_ssl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"zone_security_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"zone_security_include_hash_remote_ip": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"storage_zone_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"zone_security_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"blocked_referrers": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"last_updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourcePullZoneStateUpgradeV0 moves the origin shield and vary cache
+// attributes from the top-level of the pull zone into their nested blocks.
+func resourcePullZoneStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	moveAttributesToBlock(rawState, keyOriginShield, map[string]string{
+		"enable_origin_shield":    keyOriginShieldEnabled,
+		"origin_shield_zone_code": keyOriginShieldZoneCode,
+	})
+
+	moveAttributesToBlock(rawState, keyVaryCache, map[string]string{
+		"enable_avif_vary":         keyEnableAvifVary,
+		"enable_country_code_vary": keyEnableCountryCodeVary,
+		"enable_hostname_vary":     keyEnableHostnameVary,
+		"enable_mobile_vary":       keyEnableMobileVary,
+		"enable_webp_vary":         keyEnableWebPVary,
+	})
+
+	return rawState, nil
+}
+
+// moveAttributesToBlock moves the top-level attributes in rawState that are
+// keys of attrs to the nested block blockKey and renames them to the
+// corresponding values in attrs.
+// If none of the attributes exist, rawState is not modified.
+func moveAttributesToBlock(rawState map[string]interface{}, blockKey string, attrs map[string]string) {
+	block := map[string]interface{}{}
+
+	for oldKey, newKey := range attrs {
+		v, exists := rawState[oldKey]
+		if !exists {
+			continue
+		}
+
+		block[newKey] = v
+		delete(rawState, oldKey)
+	}
+
+	if len(block) == 0 {
+		return
+	}
+
+	rawState[blockKey] = []interface{}{block}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResourcePullZoneStateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		keyID:                                    "123",
+		keyName:                                  "pz",
+		"enable_origin_shield":                   true,
+		"origin_shield_zone_code":                "IL",
+		"cache_control_browser_max_age_override": float64(-1),
+		"cache_control_max_age_override":         float64(3600),
+		"cache_error_responses":                  false,
+		"enable_avif_vary":                       false,
+		"enable_country_code_vary":               true,
+		"enable_hostname_vary":                   false,
+		"enable_mobile_vary":                     true,
+		"enable_webp_vary":                       false,
+	}
+
+	expected := map[string]interface{}{
+		keyID:                                "123",
+		keyName:                              "pz",
+		keyCacheControlBrowserMaxAgeOverride: float64(-1),
+		keyCacheControlMaxAgeOverride:        float64(3600),
+		keyCacheErrorResponses:               false,
+		keyOriginShield: []interface{}{
+			map[string]interface{}{
+				keyOriginShieldEnabled:  true,
+				keyOriginShieldZoneCode: "IL",
+			},
+		},
+		keyVaryCache: []interface{}{
+			map[string]interface{}{
+				keyEnableAvifVary:        false,
+				keyEnableCountryCodeVary: true,
+				keyEnableHostnameVary:    false,
+				keyEnableMobileVary:      true,
+				keyEnableWebPVary:        false,
+			},
+		},
+	}
+
+	actual, err := resourcePullZoneStateUpgradeV0(context.Background(), v0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("upgraded state differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestResourcePullZoneStateUpgradeV0_attributesMissing(t *testing.T) {
+	v0 := map[string]interface{}{
+		keyID: "123",
+	}
+
+	actual, err := resourcePullZoneStateUpgradeV0(context.Background(), v0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(map[string]interface{}{keyID: "123"}, actual); diff != "" {
+		t.Errorf("upgraded state differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestResourcePullZoneV0_validSchema(t *testing.T) {
+	if err := resourcePullZoneV0().InternalValidate(nil, false); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
 
 	ptr "github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	bunny "github.com/simplesurance/bunny-go"
@@ -36,10 +39,13 @@ type pullZoneWanted struct {
 }
 
 func newAPIClient() *bunny.Client {
-	return bunny.NewClient(
-		os.Getenv(envVarAPIKey),
-		bunny.WithUserAgent(userAgent+"-test"),
-	)
+	opts := []bunny.Option{bunny.WithUserAgent(userAgent + "-test")}
+
+	if apiURL := os.Getenv(envVarAPIURL); apiURL != "" {
+		opts = append(opts, bunny.WithBaseURL(apiURL))
+	}
+
+	return bunny.NewClient(os.Getenv(envVarAPIKey), opts...)
 }
 
 func stringsAreEqual(a string, b *string) error {
@@ -148,13 +154,13 @@ func checkPullZoneNotExists(pullZoneName string) resource.TestCheckFunc {
 					}
 
 				}
+			}
 
-				if !*pullzones.HasMoreItems {
-					return nil
-				}
-
-				page++
+			if !*pullzones.HasMoreItems {
+				return nil
 			}
+
+			page++
 		}
 	}
 }
@@ -188,7 +194,7 @@ resource "bunny_pullzone" "mytest1" {
 		attrs.OriginURL,
 	)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -240,6 +246,7 @@ func TestAccPullZone_full(t *testing.T) {
 		BlockRootPathAccess:               ptr.ToBool(true),
 		BlockedCountries:                  []string{"KP", "US"},
 		BlockedIPs:                        []string{"1.1.1.1", "127.0.0.1", "::1"},
+		BlockedReferrers:                  []string{"evil.example.com", "spam.example.com"},
 		BudgetRedirectedCountries:         []string{"DE", "GB"},
 		CacheControlBrowserMaxAgeOverride: ptr.ToInt64(100),
 		CacheControlMaxAgeOverride:        ptr.ToInt64(3),
@@ -247,8 +254,16 @@ func TestAccPullZone_full(t *testing.T) {
 		ConnectionLimitPerIPCount:         ptr.ToInt32(23),
 		DisableCookies:                    ptr.ToBool(false),
 		EnableAccessControlOriginHeader:   ptr.ToBool(false),
+		EnableAutoSSL:                     ptr.ToBool(true),
+		Enabled:                           ptr.ToBool(false),
 		EnableAvifVary:                    ptr.ToBool(true),
 		EnableCacheSlice:                  ptr.ToBool(true),
+		EnableGeoZoneAF:                   ptr.ToBool(false),
+		EnableGeoZoneAsia:                 ptr.ToBool(false),
+		EnableGeoZoneEU:                   ptr.ToBool(true),
+		EnableGeoZoneSA:                   ptr.ToBool(false),
+		EnableGeoZoneUS:                   ptr.ToBool(true),
+		EnableCookieVary:                  ptr.ToBool(true),
 		EnableCountryCodeVary:             ptr.ToBool(true),
 		EnableHostnameVary:                ptr.ToBool(true),
 		EnableLogging:                     ptr.ToBool(false),
@@ -268,15 +283,30 @@ func TestAccPullZone_full(t *testing.T) {
 		LogForwardingHostname:             ptr.ToString("localhost"),
 		LogForwardingPort:                 ptr.ToInt32(22),
 		LogForwardingToken:                ptr.ToString("abcd"),
+		LogForwardingProtocol:             ptr.ToInt(bunny.LogForwardingProtocolTCPEncrypted),
+		LogForwardingFormat:               ptr.ToInt(bunny.LogFormatJSON),
+		LogFormat:                         ptr.ToInt(bunny.LogFormatJSON),
+		LogAnonymizationType:              ptr.ToInt(bunny.LogAnonymizationTypeDrop),
 		LoggingIPAnonymizationEnabled:     ptr.ToBool(false),
 		// TODO: can only be set if LoggingStorageZoneId is set to an existing storagezone
 		//LoggingSaveToStorage:             ptr.ToBool(true),
 		// TODO: Test LoggingStorageZoneId
-		MonthlyBandwidthLimit: ptr.ToInt64(10240),
-		OriginShieldZoneCode:  ptr.ToString("IL"),
-		OriginURL:             ptr.ToString("http://terraform.io"),
+		MonthlyBandwidthLimit:              ptr.ToInt64(10240),
+		LimitRatePerSecond:                 ptr.ToFloat64(512),
+		LimitRateAfter:                     ptr.ToFloat64(5),
+		BurstSize:                          ptr.ToInt32(10),
+		OriginShieldZoneCode:               ptr.ToString("IL"),
+		OriginShieldEnableConcurrencyLimit: ptr.ToBool(true),
+		OriginShieldMaxConcurrentRequests:  ptr.ToInt32(50),
+		OriginShieldMaxQueuedRequests:      ptr.ToInt32(100),
+		OriginShieldQueueMaxWaitTime:       ptr.ToInt32(10),
+		OriginHostHeader:                   ptr.ToString("terraform.io"),
+		OriginType:                         ptr.ToInt32(bunny.OriginTypeURL),
+		OriginURL:                          ptr.ToString("http://terraform.io"),
 		// TODO: Test PermaCacheStorageZoneID
 		RequestLimit:                    ptr.ToInt32(3),
+		ShieldDDosProtectionEnabled:     ptr.ToBool(true),
+		ShieldDDosProtectionType:        ptr.ToInt(bunny.DDoSProtectionTypeActiveAggressive),
 		Type:                            ptr.ToInt(1),
 		VerifyOriginSSL:                 ptr.ToBool(true),
 		ZoneSecurityEnabled:             ptr.ToBool(true),
@@ -286,6 +316,12 @@ func TestAccPullZone_full(t *testing.T) {
 		ZoneSecurityKey: ptr.ToString("xyz"),
 
 		EnableSafeHop:                       ptr.ToBool(true),
+		CookieVaryParameters:                []string{"ab_test_group"},
+		QueryStringVaryParameters:           []string{"lang", "version"},
+		EnableSmartCache:                    ptr.ToBool(true),
+		UseBackgroundUpdate:                 ptr.ToBool(true),
+		UseStaleWhileOffline:                ptr.ToBool(true),
+		UseStaleWhileUpdating:               ptr.ToBool(true),
 		AccessControlOriginHeaderExtensions: []string{"txt", "exe", "json"},
 		OriginConnectTimeout:                ptr.ToInt32(3),
 		OriginResponseTimeout:               ptr.ToInt32(45),
@@ -319,30 +355,27 @@ resource "bunny_pullzone" "%s" {
 	aws_signing_region_name = "%s"
 	aws_signing_secret = "%s"
 	allowed_referrers = %s
+	auto_ssl = %t
 	block_post_requests = %t
 	block_root_path_access = %t
 	blocked_countries = %s
 	blocked_ips = %s
+	blocked_referrers = %s
 	budget_redirected_countries = %s
 	cache_control_browser_max_age_override  = %d
 	cache_control_max_age_override = %d
 	cache_error_responses = %t
 	disable_cookies = %t
-	enable_avif_vary = %t
 	enable_cache_slice = %t
-	enable_country_code_vary = %t
-	#enable_geo_zone_af
-	#enable_geo_zone_asia
-	#enable_geo_zone_eu
-	#enable_geo_zone_sa
-	#enable_geo_zone_us
-	enable_hostname_vary = %t
+	enabled = %t
+	enable_geo_zone_af = %t
+	enable_geo_zone_asia = %t
+	enable_geo_zone_eu = %t
+	enable_geo_zone_sa = %t
+	enable_geo_zone_us = %t
 	enable_logging = %t
-	enable_mobile_vary = %t
-	enable_origin_shield = %t
 	enable_tlsv1 = %t
 	enable_tls1_1 = %t
-	enable_webp_vary = %t
 	error_page_custom_code = "%s"
 	error_page_enable_custom_code = "%t"
 	error_page_enable_statuspage_widget = %t
@@ -354,17 +387,19 @@ resource "bunny_pullzone" "%s" {
 	log_forwarding_hostname = "%s"
 	log_forwarding_port = %d
 	log_forwarding_token = "%s"
+	log_forwarding_protocol = "%s"
+	log_forwarding_format = "%s"
+	log_format = "%s"
+	log_anonymization_type = "%s"
 	# logging_ip_anonymization_enabled // the field can only bet set after signing the dpa-agreement in the webinterface
 	# logging_save_to_storage
 	# logging_storage_zone_id
-	origin_shield_zone_code = "%s"
 	origin_url = "%s"
 	# perma_cache_storage_zone_id
 	type = %d
 	verify_origin_ssl = %t
 	zone_security_enabled = %t
 	zone_security_include_hash_remote_ip = %t
-	# blocked_referrers // uses different API
 	name = "%s"
 	# storage_zone_id
 	# zone_security_key
@@ -391,6 +426,46 @@ resource "bunny_pullzone" "%s" {
 		request_limit = %d
 		monthly_bandwidth_limit = %d
 		connection_limit_per_ip_count = %d
+		limit_rate_per_second = %g
+		limit_rate_after = %g
+		burst_size = %d
+	}
+
+	cache {
+		enable_smart_cache = %t
+		use_background_update = %t
+		use_stale_while_offline = %t
+		use_stale_while_updating = %t
+	}
+
+	origin_shield {
+		enabled = %t
+		zone_code = "%s"
+		enable_concurrency_limit = %t
+		max_concurrent_requests = %d
+		max_queued_requests = %d
+		queue_max_wait_time = %d
+	}
+
+	vary_cache {
+		enable_avif_vary = %t
+		enable_cookie_vary = %t
+		enable_country_code_vary = %t
+		enable_hostname_vary = %t
+		enable_mobile_vary = %t
+		enable_webp_vary = %t
+		cookie_vary_parameters = %s
+		query_string_vary_parameters = %s
+	}
+
+	ddos_protection {
+		enabled = %t
+		type = "%s"
+	}
+
+	origin {
+		type = "%s"
+		host_header = "%s"
 	}
 
 	optimizer {
@@ -425,25 +500,27 @@ resource "bunny_pullzone" "%s" {
 		ptr.GetString(attrs.AWSSigningRegionName),
 		ptr.GetString(attrs.AWSSigningSecret),
 		tfStrList(attrs.AllowedReferrers),
+		ptr.GetBool(attrs.EnableAutoSSL),
 		ptr.GetBool(attrs.BlockPostRequests),
 		ptr.GetBool(attrs.BlockRootPathAccess),
 		tfStrList(attrs.BlockedCountries),
 		tfStrList(attrs.BlockedIPs),
+		tfStrList(attrs.BlockedReferrers),
 		tfStrList(attrs.BudgetRedirectedCountries),
 		ptr.GetInt64(attrs.CacheControlBrowserMaxAgeOverride),
 		ptr.GetInt64(attrs.CacheControlMaxAgeOverride),
 		ptr.GetBool(attrs.CacheErrorResponses),
 		ptr.GetBool(attrs.DisableCookies),
-		ptr.GetBool(attrs.EnableAvifVary),
 		ptr.GetBool(attrs.EnableCacheSlice),
-		ptr.GetBool(attrs.EnableCountryCodeVary),
-		ptr.GetBool(attrs.EnableHostnameVary),
+		ptr.GetBool(attrs.Enabled),
+		ptr.GetBool(attrs.EnableGeoZoneAF),
+		ptr.GetBool(attrs.EnableGeoZoneAsia),
+		ptr.GetBool(attrs.EnableGeoZoneEU),
+		ptr.GetBool(attrs.EnableGeoZoneSA),
+		ptr.GetBool(attrs.EnableGeoZoneUS),
 		ptr.GetBool(attrs.EnableLogging),
-		ptr.GetBool(attrs.EnableMobileVary),
-		ptr.GetBool(attrs.EnableOriginShield),
 		ptr.GetBool(attrs.EnableTLS1),
 		ptr.GetBool(attrs.EnableTLS11),
-		ptr.GetBool(attrs.EnableWebPVary),
 		ptr.GetString(attrs.ErrorPageCustomCode),
 		ptr.GetBool(attrs.ErrorPageEnableCustomCode),
 		ptr.GetBool(attrs.ErrorPageEnableStatuspageWidget),
@@ -455,9 +532,12 @@ resource "bunny_pullzone" "%s" {
 		ptr.GetString(attrs.LogForwardingHostname),
 		ptr.GetInt32(attrs.LogForwardingPort),
 		ptr.GetString(attrs.LogForwardingToken),
+		logForwardingProtocolsInt[ptr.GetInt(attrs.LogForwardingProtocol)],
+		logFormatsInt[ptr.GetInt(attrs.LogForwardingFormat)],
+		logFormatsInt[ptr.GetInt(attrs.LogFormat)],
+		logAnonymizationTypesInt[ptr.GetInt(attrs.LogAnonymizationType)],
 		// ptr.GetBool(attrs.LoggingIPAnonymizationEnabled),
 		// ptr.GetBool(attrs.LoggingSaveToStorage),
-		ptr.GetString(attrs.OriginShieldZoneCode),
 		ptr.GetString(attrs.OriginURL),
 		ptr.GetInt(attrs.Type),
 		ptr.GetBool(attrs.VerifyOriginSSL),
@@ -482,6 +562,36 @@ resource "bunny_pullzone" "%s" {
 		ptr.GetInt32(attrs.RequestLimit),
 		ptr.GetInt64(attrs.MonthlyBandwidthLimit),
 		ptr.GetInt32(attrs.ConnectionLimitPerIPCount),
+		ptr.GetFloat64(attrs.LimitRatePerSecond),
+		ptr.GetFloat64(attrs.LimitRateAfter),
+		ptr.GetInt32(attrs.BurstSize),
+
+		ptr.GetBool(attrs.EnableSmartCache),
+		ptr.GetBool(attrs.UseBackgroundUpdate),
+		ptr.GetBool(attrs.UseStaleWhileOffline),
+		ptr.GetBool(attrs.UseStaleWhileUpdating),
+
+		ptr.GetBool(attrs.EnableOriginShield),
+		ptr.GetString(attrs.OriginShieldZoneCode),
+		ptr.GetBool(attrs.OriginShieldEnableConcurrencyLimit),
+		ptr.GetInt32(attrs.OriginShieldMaxConcurrentRequests),
+		ptr.GetInt32(attrs.OriginShieldMaxQueuedRequests),
+		ptr.GetInt32(attrs.OriginShieldQueueMaxWaitTime),
+
+		ptr.GetBool(attrs.EnableAvifVary),
+		ptr.GetBool(attrs.EnableCookieVary),
+		ptr.GetBool(attrs.EnableCountryCodeVary),
+		ptr.GetBool(attrs.EnableHostnameVary),
+		ptr.GetBool(attrs.EnableMobileVary),
+		ptr.GetBool(attrs.EnableWebPVary),
+		tfStrList(attrs.CookieVaryParameters),
+		tfStrList(attrs.QueryStringVaryParameters),
+
+		ptr.GetBool(attrs.ShieldDDosProtectionEnabled),
+		ddosProtectionTypesInt[ptr.GetInt(attrs.ShieldDDosProtectionType)],
+
+		originTypesInt[int(ptr.GetInt32(attrs.OriginType))],
+		ptr.GetString(attrs.OriginHostHeader),
 
 		ptr.GetBool(attrs.OptimizerEnabled),
 		ptr.GetBool(attrs.OptimizerEnableWebP),
@@ -502,7 +612,7 @@ resource "bunny_pullzone" "%s" {
 		ptr.GetInt(attrs.OptimizerWatermarkPosition),
 	)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -527,7 +637,7 @@ resource "bunny_pullzone" "%s" {
 func TestAccPullZone_CaseInsensitiveOrderIndependentFields(t *testing.T) {
 	pzName := randResourceName()
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -708,45 +818,16 @@ func strSliceDiff(a, b []string) string {
 // pullZoneDiffIgnoredFields contains a list of fieldsnames in a bunny.PullZone struct that are ignored by pzDiff.
 var pullZoneDiffIgnoredFields = map[string]struct{}{
 	"AccessControlOriginHeaderExtensions": {}, // computed field
-	"BlockedReferrers":                    {}, // computed field
 	"CnameDomain":                         {}, // computed field
-	"EnableGeoZoneAF":                     {}, // computed field
-	"EnableGeoZoneAsia":                   {}, // computed field
-	"EnableGeoZoneEU":                     {}, // computed field
-	"EnableGeoZoneSA":                     {}, // computed field
-	"EnableGeoZoneUS":                     {}, // computed field
-	"Enabled":                             {}, // computed field
 	"ID":                                  {}, // computed field
 	"LoggingIPAnonymizationEnabled":       {}, // can only bet set if DPA agreement was signed in the webinterface
 	"VideoLibraryID":                      {}, // computed field
 	"ZoneSecurityKey":                     {}, // computed field
 
 	// the following fields are ignored because they are not implemented in the provider
-	"BurstSize":                          {},
-	"CacheErrorResponses":                {},
-	"DNSRecordID":                        {},
-	"DNSZoneID":                          {},
-	"EnableAutoSSL":                      {},
-	"EnableCookieVary":                   {},
-	"EnableSmartCache":                   {},
-	"LimitRateAfter":                     {},
-	"LimitRatePerSecond":                 {},
-	"LogAnonymizationType":               {},
-	"LogFormat":                          {},
-	"LogForwardingFormat":                {},
-	"LogForwardingProtocol":              {},
-	"OptimizerForceClasses":              {},
-	"OriginHostHeader":                   {},
-	"OriginShieldEnableConcurrencyLimit": {},
-	"OriginShieldMaxConcurrentRequests":  {},
-	"OriginShieldMaxQueuedRequests":      {},
-	"OriginShieldQueueMaxWaitTime":       {},
-	"OriginType":                         {},
-	"ShieldDDosProtectionEnabled":        {},
-	"ShieldDDosProtectionType":           {},
-	"UseBackgroundUpdate":                {},
-	"UseStaleWhileOffline":               {},
-	"UseStaleWhileUpdating":              {},
+	"DNSRecordID":           {},
+	"DNSZoneID":             {},
+	"OptimizerForceClasses": {},
 
 	// The following fields are tested by separate testcases and ignored in
 	// pull zone testcases.
@@ -766,6 +847,10 @@ var pullZoneDiffIgnoredFields = map[string]struct{}{
 	"LoggingSaveToStorageZoneID": {},
 	"StorageZoneID":              {},
 	"LoggingStorageZoneID":       {},
+
+	// the following fields can not be tested because they require an edge
+	// script, which currently can not be created via the provider
+	"EdgeScriptID": {},
 }
 
 func pzDiff(t *testing.T, a, b interface{}) []string {
@@ -776,7 +861,7 @@ func pzDiff(t *testing.T, a, b interface{}) []string {
 func TestAccPullZone_OriginURLAndStorageZoneIDAreExclusive(t *testing.T) {
 	pzName := randResourceName()
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -791,3 +876,860 @@ resource "bunny_pullzone" "testpz" {
 		},
 	})
 }
+
+func TestFakeAPIPullZone_createReadDelete(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+
+	d := schema.TestResourceDataRaw(t, resourcePullZone().Schema, map[string]interface{}{
+		keyName:            "testpz",
+		keyOriginURL:       "https://bunny.net",
+		keyFollowRedirects: true,
+	})
+
+	if diags := resourcePullZoneCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	id, err := getIDAsInt64(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pz, exists := srv.PullZone(id)
+	if !exists {
+		t.Fatalf("pull zone %d does not exist in fake api", id)
+	}
+
+	if !ptr.GetBool(pz.FollowRedirects) {
+		t.Errorf("FollowRedirects was not set via update after creation")
+	}
+
+	if diags := resourcePullZoneRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read failed: %+v", diags)
+	}
+
+	if cname := d.Get(keyCnameDomain).(string); cname != "testpz.b-cdn.net" {
+		t.Errorf("unexpected %s: %q", keyCnameDomain, cname)
+	}
+
+	if diags := resourcePullZoneDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete failed: %+v", diags)
+	}
+
+	if _, exists := srv.PullZone(id); exists {
+		t.Errorf("pull zone %d still exists after deletion", id)
+	}
+}
+
+// newFakeAPIPullZone creates a pull zone via the provider client and returns
+// its ID.
+func newFakeAPIPullZone(t *testing.T, meta interface{}) int64 {
+	t.Helper()
+
+	pz, err := meta.(*client).PullZone.Add(context.Background(), &bunny.PullZoneAddOptions{
+		Name:      randResourceName(),
+		OriginURL: "https://bunny.net",
+	})
+	if err != nil {
+		t.Fatalf("creating pull zone failed: %s", err)
+	}
+
+	return *pz.ID
+}
+
+func TestFakeAPIPullZone_readRemovesDeletedPullZone(t *testing.T) {
+	ctx := context.Background()
+	_, meta := newFakeAPIProvider(t)
+	pzID := newFakeAPIPullZone(t, meta)
+
+	d := schema.TestResourceDataRaw(t, resourcePullZone().Schema, map[string]interface{}{})
+	d.SetId(strconv.FormatInt(pzID, 10))
+
+	if err := meta.(*client).PullZone.Delete(ctx, pzID); err != nil {
+		t.Fatal(err)
+	}
+
+	assertRemovedFromState(t, d, resourcePullZoneRead(ctx, d, meta))
+}
+
+func TestFakeAPIPullZone_blockedReferrers(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	res := resourcePullZone()
+
+	config := map[string]interface{}{
+		keyName:             randResourceName(),
+		keyOriginURL:        "https://bunny.net",
+		keyBlockedReferrers: []interface{}{"a.example.com", "b.example.com"},
+	}
+
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	if diags := resourcePullZoneCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	id, err := getIDAsInt64(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertBlockedReferrers := func(t *testing.T, want ...string) {
+		t.Helper()
+
+		pz, _ := srv.PullZone(id)
+		got := append([]string{}, pz.BlockedReferrers...)
+		sort.Strings(got)
+
+		if diff := strSliceDiff(want, got); diff != "" {
+			t.Errorf("unexpected blocked referrers in api: %s", diff)
+		}
+
+		if stateCnt := d.Get(keyBlockedReferrers).(*schema.Set).Len(); stateCnt != len(want) {
+			t.Errorf("state contains %d blocked referrers, expected %d", stateCnt, len(want))
+		}
+	}
+
+	assertBlockedReferrers(t, "a.example.com", "b.example.com")
+
+	config[keyBlockedReferrers] = []interface{}{"b.example.com", "c.example.com"}
+	d = testResourceDataUpdate(t, res, d, config, meta)
+	if diags := resourcePullZoneUpdate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update failed: %+v", diags)
+	}
+
+	assertBlockedReferrers(t, "b.example.com", "c.example.com")
+
+	// changing only the case of referrers must not remove and re-add them
+	config[keyBlockedReferrers] = []interface{}{"B.example.com", "c.EXAMPLE.com"}
+	d = testResourceDataUpdate(t, res, d, config, meta)
+	if diags := resourcePullZoneUpdate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update failed: %+v", diags)
+	}
+
+	assertBlockedReferrers(t, "b.example.com", "c.example.com")
+
+	delete(config, keyBlockedReferrers)
+	d = testResourceDataUpdate(t, res, d, config, meta)
+	if diags := resourcePullZoneUpdate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update failed: %+v", diags)
+	}
+
+	assertBlockedReferrers(t)
+}
+
+// pullZoneSetFieldsDiff compares the fields of got with the fields that are
+// set in want. Fields that are nil in want are ignored.
+func pullZoneSetFieldsDiff(t *testing.T, want, got *bunny.PullZone) []string {
+	t.Helper()
+	var res []string
+
+	valWant := reflect.ValueOf(want).Elem()
+	valGot := reflect.ValueOf(got).Elem()
+
+	for i := 0; i < valWant.NumField(); i++ {
+		fieldWant := valWant.Field(i)
+		if fieldWant.IsNil() {
+			continue
+		}
+
+		name := valWant.Type().Field(i).Name
+		a := fieldWant.Interface()
+		b := valGot.Field(i).Interface()
+
+		// the order of set elements is not retained
+		if sl, ok := a.([]string); ok {
+			a = sortedStrings(sl)
+			b = sortedStrings(b.([]string))
+		}
+
+		diff, err := diffSimpleVal(reflect.ValueOf(a), reflect.ValueOf(b))
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+
+		if diff != "" {
+			res = append(res, fmt.Sprintf("%s: %s", name, diff))
+		}
+	}
+
+	return res
+}
+
+func sortedStrings(sl []string) []string {
+	res := append([]string{}, sl...)
+	sort.Strings(res)
+	return res
+}
+
+func TestFakeAPIPullZone_createRead(t *testing.T) {
+	testcases := []struct {
+		name   string
+		config map[string]interface{}
+		want   bunny.PullZone
+	}{
+		{
+			name: "cache",
+			config: map[string]interface{}{
+				keyCacheControlMaxAgeOverride: 3600,
+				keyCache: []interface{}{
+					map[string]interface{}{
+						keyCacheUseStaleWhileOffline: true,
+						keyCacheUseBackgroundUpdate:  true,
+					},
+				},
+			},
+			want: bunny.PullZone{
+				CacheControlMaxAgeOverride:        ptr.ToInt64(3600),
+				CacheControlBrowserMaxAgeOverride: ptr.ToInt64(-1),
+				UseStaleWhileOffline:              ptr.ToBool(true),
+				UseBackgroundUpdate:               ptr.ToBool(true),
+				UseStaleWhileUpdating:             ptr.ToBool(false),
+			},
+		},
+		{
+			name: "origin shield",
+			config: map[string]interface{}{
+				keyOriginShield: []interface{}{
+					map[string]interface{}{
+						keyOriginShieldEnabled:                true,
+						keyOriginShieldZoneCode:               "IL",
+						keyOriginShieldEnableConcurrencyLimit: true,
+						keyOriginShieldMaxConcurrentRequests:  25,
+					},
+				},
+			},
+			want: bunny.PullZone{
+				EnableOriginShield:                 ptr.ToBool(true),
+				OriginShieldZoneCode:               ptr.ToString("IL"),
+				OriginShieldEnableConcurrencyLimit: ptr.ToBool(true),
+				OriginShieldMaxConcurrentRequests:  ptr.ToInt32(25),
+				OriginShieldQueueMaxWaitTime:       ptr.ToInt32(30),
+			},
+		},
+		{
+			name: "vary cache",
+			config: map[string]interface{}{
+				keyIgnoreQueryStrings: false,
+				keyVaryCache: []interface{}{
+					map[string]interface{}{
+						keyEnableCookieVary:          true,
+						keyEnableMobileVary:          true,
+						keyCookieVaryParameters:      []interface{}{"ab_test_group"},
+						keyQueryStringVaryParameters: []interface{}{"lang", "version"},
+					},
+				},
+			},
+			want: bunny.PullZone{
+				EnableCookieVary:          ptr.ToBool(true),
+				EnableMobileVary:          ptr.ToBool(true),
+				CookieVaryParameters:      []string{"ab_test_group"},
+				QueryStringVaryParameters: []string{"lang", "version"},
+			},
+		},
+		{
+			name: "limits",
+			config: map[string]interface{}{
+				keyLimits: []interface{}{
+					map[string]interface{}{
+						keyLimitsLimitRatePerSecond: 512.5,
+						keyLimitsLimitRateAfter:     5.0,
+						keyLimitsBurstSize:          10,
+					},
+				},
+			},
+			want: bunny.PullZone{
+				LimitRatePerSecond: ptr.ToFloat64(512.5),
+				LimitRateAfter:     ptr.ToFloat64(5),
+				BurstSize:          ptr.ToInt32(10),
+			},
+		},
+		{
+			name: "ddos protection",
+			config: map[string]interface{}{
+				keyDDoSProtection: []interface{}{
+					map[string]interface{}{
+						keyDDoSProtectionType: "active_aggressive",
+					},
+				},
+			},
+			want: bunny.PullZone{
+				ShieldDDosProtectionEnabled: ptr.ToBool(true),
+				ShieldDDosProtectionType:    ptr.ToInt(bunny.DDoSProtectionTypeActiveAggressive),
+			},
+		},
+		{
+			name: "log forwarding",
+			config: map[string]interface{}{
+				keyLogForwardingEnabled:  true,
+				keyLogForwardingHostname: "siem.example.com",
+				keyLogForwardingPort:     6514,
+				keyLogForwardingProtocol: "tls",
+				keyLogForwardingFormat:   "json",
+				keyLogAnonymizationType:  "drop",
+			},
+			want: bunny.PullZone{
+				LogForwardingProtocol: ptr.ToInt(bunny.LogForwardingProtocolTCPEncrypted),
+				LogForwardingFormat:   ptr.ToInt(bunny.LogFormatJSON),
+				LogFormat:             ptr.ToInt(bunny.LogFormatPlain),
+				LogAnonymizationType:  ptr.ToInt(bunny.LogAnonymizationTypeDrop),
+			},
+		},
+		{
+			name: "origin",
+			config: map[string]interface{}{
+				keyOrigin: []interface{}{
+					map[string]interface{}{
+						keyOriginType:       "dns_accelerate",
+						keyOriginHostHeader: "www.bunny.net",
+					},
+				},
+			},
+			want: bunny.PullZone{
+				OriginType:       ptr.ToInt32(bunny.OriginTypeDNSAccelerate),
+				OriginHostHeader: ptr.ToString("www.bunny.net"),
+			},
+		},
+		{
+			name: "storage zone origin",
+			config: map[string]interface{}{
+				keyStorageZoneID: 100,
+			},
+			want: bunny.PullZone{
+				OriginType:    ptr.ToInt32(bunny.OriginTypeStorageZone),
+				StorageZoneID: ptr.ToInt64(100),
+			},
+		},
+		{
+			name: "disabled",
+			config: map[string]interface{}{
+				keyEnabled: false,
+			},
+			want: bunny.PullZone{
+				Enabled: ptr.ToBool(false),
+			},
+		},
+		{
+			name: "geo zones",
+			config: map[string]interface{}{
+				keyType:              pullZoneTypeVolume,
+				keyEnableGeoZoneAF:   false,
+				keyEnableGeoZoneAsia: false,
+				keyEnableGeoZoneSA:   false,
+			},
+			want: bunny.PullZone{
+				EnableGeoZoneAF:   ptr.ToBool(false),
+				EnableGeoZoneAsia: ptr.ToBool(false),
+				EnableGeoZoneSA:   ptr.ToBool(false),
+				// geo zones that are not configured keep the value of the API
+				EnableGeoZoneEU: ptr.ToBool(true),
+				EnableGeoZoneUS: ptr.ToBool(true),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv, meta := newFakeAPIProvider(t)
+			res := resourcePullZone()
+
+			tc.config[keyName] = randResourceName()
+			if _, exists := tc.config[keyStorageZoneID]; !exists {
+				tc.config[keyOriginURL] = "https://bunny.net"
+			}
+
+			d := testResourceDataCreate(t, res, tc.config)
+			if diags := resourcePullZoneCreate(ctx, d, meta); diags.HasError() {
+				t.Fatalf("create failed: %+v", diags)
+			}
+
+			id, err := getIDAsInt64(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pz, _ := srv.PullZone(id)
+			if diff := pullZoneSetFieldsDiff(t, &tc.want, pz); len(diff) != 0 {
+				t.Errorf("unexpected pull zone settings in api:\n%s", strings.Join(diff, "\n"))
+			}
+
+			if diags := resourcePullZoneRead(ctx, d, meta); diags.HasError() {
+				t.Fatalf("read failed: %+v", diags)
+			}
+
+			// the state that was read must match the configuration
+			state := d.State()
+			state.RawConfig = testRawConfig(t, res, tc.config)
+
+			diff, err := res.Diff(ctx, state, terraform.NewResourceConfigRaw(tc.config), meta)
+			if err != nil {
+				t.Fatalf("creating diff failed: %s", err)
+			}
+
+			if !diff.Empty() {
+				for k, v := range diff.Attributes {
+					t.Errorf("%s in state differs from the configuration after reading: %q != %q", k, v.Old, v.New)
+				}
+			}
+		})
+	}
+}
+
+func TestPullZone_originShieldLimitsValidation(t *testing.T) {
+	testcases := []struct {
+		key         string
+		value       int
+		expectError bool
+	}{
+		{key: keyOriginShieldMaxConcurrentRequests, value: -1, expectError: true},
+		{key: keyOriginShieldMaxConcurrentRequests, value: 0, expectError: true},
+		{key: keyOriginShieldMaxConcurrentRequests, value: 1},
+		{key: keyOriginShieldMaxQueuedRequests, value: -1, expectError: true},
+		{key: keyOriginShieldMaxQueuedRequests, value: 0},
+		{key: keyOriginShieldQueueMaxWaitTime, value: -1, expectError: true},
+		{key: keyOriginShieldQueueMaxWaitTime, value: 0},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s=%d", tc.key, tc.value), func(t *testing.T) {
+			diags := resourcePullZone().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				keyName:      "pz",
+				keyOriginURL: "https://bunny.net",
+				keyOriginShield: []interface{}{
+					map[string]interface{}{
+						tc.key: tc.value,
+					},
+				},
+			}))
+
+			if tc.expectError && !diags.HasError() {
+				t.Errorf("validation succeeded, expected an error")
+			}
+			if !tc.expectError && diags.HasError() {
+				t.Errorf("validation failed: %+v", diags)
+			}
+		})
+	}
+}
+
+func TestPullZone_varyCacheParametersRequireToggle(t *testing.T) {
+	testcases := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "cookie parameters with cookie vary disabled",
+			config: map[string]interface{}{
+				keyVaryCache: []interface{}{
+					map[string]interface{}{
+						keyCookieVaryParameters: []interface{}{"ab_test_group"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cookie parameters with cookie vary enabled",
+			config: map[string]interface{}{
+				keyVaryCache: []interface{}{
+					map[string]interface{}{
+						keyEnableCookieVary:     true,
+						keyCookieVaryParameters: []interface{}{"ab_test_group"},
+					},
+				},
+			},
+		},
+		{
+			name: "query string parameters with ignored query strings",
+			config: map[string]interface{}{
+				keyVaryCache: []interface{}{
+					map[string]interface{}{
+						keyQueryStringVaryParameters: []interface{}{"lang"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "query string parameters with query string vary",
+			config: map[string]interface{}{
+				keyIgnoreQueryStrings: false,
+				keyVaryCache: []interface{}{
+					map[string]interface{}{
+						keyQueryStringVaryParameters: []interface{}{"lang"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config[keyName] = "pz"
+			tc.config[keyOriginURL] = "https://bunny.net"
+
+			err := testPlanCreate(t, "bunny_pullzone", tc.config)
+			if tc.wantErr && err == nil {
+				t.Fatal("planning succeeded, expected an error")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("planning failed: %s", err)
+			}
+		})
+	}
+}
+
+func TestPullZone_logForwardingRequiresDestination(t *testing.T) {
+	testcases := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "forwarding disabled",
+			config: map[string]interface{}{},
+		},
+		{
+			name: "forwarding without hostname",
+			config: map[string]interface{}{
+				keyLogForwardingEnabled: true,
+				keyLogForwardingPort:    514,
+			},
+			wantErr: true,
+		},
+		{
+			name: "forwarding without port",
+			config: map[string]interface{}{
+				keyLogForwardingEnabled:  true,
+				keyLogForwardingHostname: "siem.example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "forwarding with destination",
+			config: map[string]interface{}{
+				keyLogForwardingEnabled:  true,
+				keyLogForwardingHostname: "siem.example.com",
+				keyLogForwardingPort:     514,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config[keyName] = "pz"
+			tc.config[keyOriginURL] = "https://bunny.net"
+
+			err := testPlanCreate(t, "bunny_pullzone", tc.config)
+			if tc.wantErr && err == nil {
+				t.Fatal("planning succeeded, expected an error")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("planning failed: %s", err)
+			}
+		})
+	}
+}
+
+func TestFakeAPIPullZone_changingOriginKeepsPullZone(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	res := resourcePullZone()
+
+	config := map[string]interface{}{
+		keyName:          randResourceName(),
+		keyStorageZoneID: 100,
+	}
+
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	if diags := resourcePullZoneCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	id, err := getIDAsInt64(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	delete(config, keyStorageZoneID)
+	config[keyOriginURL] = "https://bunny.net"
+
+	diff, err := res.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("creating diff failed: %s", err)
+	}
+
+	if diff.RequiresNew() {
+		t.Fatal("changing the origin requires to recreate the pull zone")
+	}
+
+	d = testResourceDataUpdate(t, res, d, config, meta)
+	if diags := resourcePullZoneUpdate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update failed: %+v", diags)
+	}
+
+	pz, exists := srv.PullZone(id)
+	if !exists {
+		t.Fatal("pull zone does not exist anymore after the update")
+	}
+
+	if v := ptr.GetInt32(pz.OriginType); v != bunny.OriginTypeURL {
+		t.Errorf("OriginType is %d, expected %d", v, bunny.OriginTypeURL)
+	}
+
+	if v := ptr.GetString(pz.OriginURL); v != "https://bunny.net" {
+		t.Errorf("OriginURL is %q, expected https://bunny.net", v)
+	}
+
+	if v := ptr.GetInt64(pz.StorageZoneID); v != 0 {
+		t.Errorf("StorageZoneID is %d, expected 0", v)
+	}
+
+	if v := d.Get(keyOrigin + ".0." + keyOriginType).(string); v != originTypeURL {
+		t.Errorf("%s.%s in state is %q, expected %s", keyOrigin, keyOriginType, v, originTypeURL)
+	}
+}
+
+func TestFakeAPIPullZone_originIsReadWithoutBlock(t *testing.T) {
+	ctx := context.Background()
+	_, meta := newFakeAPIProvider(t)
+	res := resourcePullZone()
+
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		keyName:          randResourceName(),
+		keyStorageZoneID: 100,
+	})
+	if diags := resourcePullZoneCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	// importing a pull zone only sets the id
+	imported := res.Data(nil)
+	imported.SetId(d.Id())
+	if diags := resourcePullZoneRead(ctx, imported, meta); diags.HasError() {
+		t.Fatalf("read failed: %+v", diags)
+	}
+
+	for _, d := range []*schema.ResourceData{d, imported} {
+		if v := d.Get(keyOrigin + ".0." + keyOriginType).(string); v != originTypeStorageZone {
+			t.Errorf("%s.%s in state is %q, expected %s", keyOrigin, keyOriginType, v, originTypeStorageZone)
+		}
+	}
+}
+
+func TestPullZone_originRequiresMatchingAttributes(t *testing.T) {
+	originBlock := func(m map[string]interface{}) []interface{} {
+		return []interface{}{m}
+	}
+
+	testcases := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "origin_url without block",
+			config: map[string]interface{}{
+				keyOriginURL: "https://bunny.net",
+			},
+		},
+		{
+			name:    "no origin",
+			config:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name: "url type with origin_url",
+			config: map[string]interface{}{
+				keyOriginURL: "https://bunny.net",
+				keyOrigin:    originBlock(map[string]interface{}{keyOriginType: "url"}),
+			},
+		},
+		{
+			name: "url type with storage_zone_id",
+			config: map[string]interface{}{
+				keyStorageZoneID: 100,
+				keyOrigin:        originBlock(map[string]interface{}{keyOriginType: "url"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "storage_zone type with storage_zone_id",
+			config: map[string]interface{}{
+				keyStorageZoneID: 100,
+				keyOrigin:        originBlock(map[string]interface{}{keyOriginType: "storage_zone"}),
+			},
+		},
+		{
+			name: "script type without script_id",
+			config: map[string]interface{}{
+				keyOrigin: originBlock(map[string]interface{}{keyOriginType: "script"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "script type with script_id",
+			config: map[string]interface{}{
+				keyOrigin: originBlock(map[string]interface{}{
+					keyOriginType:     "script",
+					keyOriginScriptID: 5,
+				}),
+			},
+		},
+		{
+			name: "script type with origin_url",
+			config: map[string]interface{}{
+				keyOriginURL: "https://bunny.net",
+				keyOrigin: originBlock(map[string]interface{}{
+					keyOriginType:     "script",
+					keyOriginScriptID: 5,
+				}),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config[keyName] = "pz"
+
+			err := testPlanCreate(t, "bunny_pullzone", tc.config)
+			if tc.wantErr && err == nil {
+				t.Fatal("planning succeeded, expected an error")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("planning failed: %s", err)
+			}
+		})
+	}
+}
+
+func TestFakeAPIPullZone_reenableAfterDisabledByBunny(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+	res := resourcePullZone()
+
+	config := map[string]interface{}{
+		keyName:      randResourceName(),
+		keyOriginURL: "https://bunny.net",
+	}
+
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	if diags := resourcePullZoneCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	id, err := getIDAsInt64(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate that bunny.net disabled the pull zone because it exceeded
+	// its bandwidth limit
+	clt := meta.(*client)
+	_, err = clt.PullZone.Update(ctx, id, &bunny.PullZoneUpdateOptions{Enabled: ptr.ToBool(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clt.pullZoneCache.Invalidate(id)
+
+	if diags := resourcePullZoneRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read failed: %+v", diags)
+	}
+
+	diff, err := res.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("creating diff failed: %s", err)
+	}
+
+	if diff == nil || diff.Attributes[keyEnabled] == nil {
+		t.Fatalf("diff does not contain a change of %s: %+v", keyEnabled, diff)
+	}
+
+	d = testResourceDataUpdate(t, res, d, config, meta)
+	if diags := resourcePullZoneUpdate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update failed: %+v", diags)
+	}
+
+	pz, _ := srv.PullZone(id)
+	if !ptr.GetBool(pz.Enabled) {
+		t.Error("pull zone is disabled after the update, expected it to be enabled")
+	}
+}
+
+func TestPullZone_geoZonesValidation(t *testing.T) {
+	testcases := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "geo zones unset",
+			config: map[string]interface{}{},
+		},
+		{
+			name: "all geo zones disabled",
+			config: map[string]interface{}{
+				keyEnableGeoZoneAF:   false,
+				keyEnableGeoZoneAsia: false,
+				keyEnableGeoZoneEU:   false,
+				keyEnableGeoZoneSA:   false,
+				keyEnableGeoZoneUS:   false,
+			},
+			wantErr: true,
+		},
+		{
+			name: "volume tier restricted to eu and us",
+			config: map[string]interface{}{
+				keyType:              pullZoneTypeVolume,
+				keyEnableGeoZoneAF:   false,
+				keyEnableGeoZoneAsia: false,
+				keyEnableGeoZoneSA:   false,
+			},
+		},
+		{
+			name: "volume tier with africa",
+			config: map[string]interface{}{
+				keyType:            pullZoneTypeVolume,
+				keyEnableGeoZoneAF: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "budget redirect with geo zones unset",
+			config: map[string]interface{}{
+				keyBudgetRedirectedCountries: []interface{}{"IN"},
+			},
+		},
+		{
+			name: "country is blocked and redirected",
+			config: map[string]interface{}{
+				keyBlockedCountries:          []interface{}{"kp", "IN"},
+				keyBudgetRedirectedCountries: []interface{}{"KP"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config[keyName] = "pz"
+			tc.config[keyOriginURL] = "https://bunny.net"
+
+			err := testPlanCreate(t, "bunny_pullzone", tc.config)
+			if tc.wantErr && err == nil {
+				t.Fatal("planning succeeded, expected an error")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("planning failed: %s", err)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ const (
 	keyReadOnlyPassword   = "read_only_password"
 	keyCustom404FilePath  = "custom_404_file_path"
 	keyRewrite404To200    = "rewrite_404_to_200"
+	keyStorageHostname    = "hostname"
 )
 
 var (
@@ -56,6 +57,7 @@ func resourceStorageZone() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: defaultResourceTimeouts(),
 
 		Schema: map[string]*schema.Schema{
 			// immutable properties
@@ -145,6 +147,11 @@ func resourceStorageZone() *schema.Resource {
 				Computed:    true,
 				Sensitive:   true,
 			},
+			keyStorageHostname: {
+				Type:        schema.TypeString,
+				Description: "The hostname of the storage API endpoint of the storage zone.",
+				Computed:    true,
+			},
 		},
 
 		CustomizeDiff: customdiff.All(
@@ -267,7 +274,7 @@ func creatingRegionWithReplicationInSameRegionError(region string) error {
 }
 
 func resourceStorageZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	originURL := getStrPtr(d, keyOriginURL)
 	if !d.HasChange(keyOriginURL) {
@@ -310,7 +317,7 @@ func resourceStorageZoneCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceStorageZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	storageZone := storageZoneFromResource(d)
 
@@ -333,7 +340,7 @@ func resourceStorageZoneUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceStorageZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	id, err := getIDAsInt64(d)
 	if err != nil {
@@ -342,6 +349,10 @@ func resourceStorageZoneRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	sz, err := clt.StorageZone.Get(ctx, id)
 	if err != nil {
+		if isNotFoundErr(err) {
+			return removeFromState(d, "storage zone", err)
+		}
+
 		return diagsErrFromErr("could not retrieve storage zone", err)
 	}
 
@@ -353,7 +364,7 @@ func resourceStorageZoneRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceStorageZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*client)
 
 	id, err := getIDAsInt64(d)
 	if err != nil {
@@ -400,6 +411,9 @@ func storageZoneToResource(sz *bunny.StorageZone, d *schema.ResourceData) error
 	if err := d.Set(keyReadOnlyPassword, sz.ReadOnlyPassword); err != nil {
 		return err
 	}
+	if err := d.Set(keyStorageHostname, sz.StorageHostname); err != nil {
+		return err
+	}
 	if err := setStrSet(d, keyReplicationRegions, sz.ReplicationRegions, ignoreOrderOpt, caseInsensitiveOpt); err != nil {
 		return err
 	}
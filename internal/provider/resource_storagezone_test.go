@@ -11,6 +11,7 @@ import (
 
 	ptr "github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	bunny "github.com/simplesurance/bunny-go"
@@ -77,13 +78,13 @@ func checkStorageZoneNotExists(storageZoneName string) resource.TestCheckFunc {
 					}
 
 				}
+			}
 
-				if !*storagezones.HasMoreItems {
-					return nil
-				}
-
-				page++
+			if !*storagezones.HasMoreItems {
+				return nil
 			}
+
+			page++
 		}
 	}
 }
@@ -105,7 +106,7 @@ resource "bunny_storagezone" "mytest1" {
 		attrs.Region,
 	)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -152,7 +153,7 @@ resource "bunny_storagezone" "%s" {
 		false,
 	)
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -185,7 +186,7 @@ func TestChangingImmutableFieldsFails(t *testing.T) {
 		ReplicationRegions: []string{"DE"},
 	}
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			// create storagezone
@@ -265,7 +266,7 @@ resource "bunny_storagezone" "mytest1" {
 func TestRegionsRequiringReplicationWithoutReplicationFails(t *testing.T) {
 	storageZoneName := randResourceName()
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -287,7 +288,7 @@ resource "bunny_storagezone" "mytest1" {
 func TestReplicaRegionSameAsMainFails(t *testing.T) {
 	storageZoneName := randResourceName()
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -354,7 +355,7 @@ func TestAccFailedUpdateDoesNotApplyChanges(t *testing.T) {
 		Region:                "DE",
 	}
 
-	resource.Test(t, resource.TestCase{
+	accTest(t, resource.TestCase{
 		Providers: testProviders,
 		Steps: []resource.TestStep{
 			{
@@ -410,6 +411,7 @@ var storageZoneDiffIgnoredFields = map[string]struct{}{
 	"StorageUsed":      {}, // computed field
 	"FilesStored":      {}, // computed field
 	"ReadOnlyPassword": {}, // computed field
+	"StorageHostname":  {}, // computed field
 
 	// The following fields are tested by separate testcases and ignored in
 	// storage zone testcases.
@@ -420,3 +422,52 @@ func szDiff(t *testing.T, a, b interface{}) []string {
 	t.Helper()
 	return diffStructs(t, a, b, storageZoneDiffIgnoredFields)
 }
+
+func TestFakeAPIStorageZone_createReadDelete(t *testing.T) {
+	ctx := context.Background()
+	srv, meta := newFakeAPIProvider(t)
+
+	d := schema.TestResourceDataRaw(t, resourceStorageZone().Schema, map[string]interface{}{
+		keyName:               randResourceName(),
+		keyRegion:             "NY",
+		keyReplicationRegions: []interface{}{"DE"},
+	})
+
+	if diags := resourceStorageZoneCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %+v", diags)
+	}
+
+	if diags := resourceStorageZoneRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read failed: %+v", diags)
+	}
+
+	if region := d.Get(keyRegion).(string); region != "NY" {
+		t.Errorf("unexpected %s: %q", keyRegion, region)
+	}
+
+	if d.Get(keyPassword).(string) == "" {
+		t.Errorf("%s is empty", keyPassword)
+	}
+
+	id, err := getIDAsInt64(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceStorageZoneDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete failed: %+v", diags)
+	}
+
+	if _, exists := srv.StorageZone(id); exists {
+		t.Errorf("storage zone %d still exists after deletion", id)
+	}
+}
+
+func TestFakeAPIStorageZone_readRemovesDeletedStorageZone(t *testing.T) {
+	_, meta := newFakeAPIProvider(t)
+
+	d := schema.TestResourceDataRaw(t, resourceStorageZone().Schema, map[string]interface{}{})
+	d.SetId("12345")
+
+	assertRemovedFromState(t, d, resourceStorageZoneRead(context.Background(), d, meta))
+}
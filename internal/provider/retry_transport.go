@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const retryMinWait = time.Second
+
+// retryTransport is an http.RoundTripper that retries requests that failed
+// because of rate limiting or temporary server errors.
+//
+// Requests that were answered with 429 (Too Many Requests) are retried
+// independent of their method, the server did not process them.
+// Requests that failed with a 5xx status code or a connection error are only
+// retried if their method is idempotent, otherwise retrying could apply a
+// change twice.
+// Between retries the transport waits with an exponential backoff with
+// jitter, if the response contains a Retry-After header its value is used
+// instead. The wait time never exceeds maxWait.
+// If the request context would expire before the next retry, the last
+// response is returned without waiting.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minWait:    retryMinWait,
+		maxWait:    maxWait,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+
+		if attempt >= t.maxRetries || !isRetryable(req, resp, err) || !canRewindBody(req) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		// the context would expire while waiting, return the last
+		// result instead of a less meaningful context error
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+
+		if err != nil {
+			logger.Warnf("%s %s failed: %s, retrying in %s (%d/%d)", req.Method, req.URL, err, wait, attempt+1, t.maxRetries)
+		} else {
+			logger.Warnf("%s %s failed with status code %d, retrying in %s (%d/%d)", req.Method, req.URL, resp.StatusCode, wait, attempt+1, t.maxRetries)
+			drainBody(resp)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req, err = rewindBody(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns how long to wait before the next retry.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+
+			return wait
+		}
+	}
+
+	wait := t.minWait << attempt
+	if wait > t.maxWait || wait <= 0 {
+		wait = t.maxWait
+	}
+
+	// randomize the wait time between [wait/2, wait) to prevent that
+	// concurrent requests are retried at the same time
+	half := int64(wait / 2)
+	if half <= 0 {
+		return wait
+	}
+
+	return time.Duration(half + rand.Int63n(half)) //nolint:gosec // no cryptographic randomness required
+}
+
+func parseRetryAfter(val string) (time.Duration, bool) {
+	if val == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(val); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(val); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// canRewindBody returns true if the body of req can be sent again.
+func canRewindBody(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func rewindBody(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	newReq := req.Clone(req.Context())
+	newReq.Body = body
+
+	return newReq, nil
+}
+
+// drainBody reads and closes the response body, to allow reusing the
+// connection.
+func drainBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+}
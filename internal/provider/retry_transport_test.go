@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFailingServer starts a server that responds with failStatus to the
+// first failCount requests and with 200 to all following requests.
+// The returned counter contains the number of received requests.
+func newFailingServer(t *testing.T, failStatus, failCount int) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var cnt int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&cnt, 1)
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request body failed: %s", err)
+		}
+
+		if r.Method == http.MethodPost && string(body) != "body" {
+			t.Errorf("request %d has unexpected body: %q", n, string(body))
+		}
+
+		if int(n) <= failCount {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(failStatus)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &cnt
+}
+
+func newTestRetryClient(maxRetries int) *http.Client {
+	transport := newRetryTransport(http.DefaultTransport, maxRetries, 10*time.Millisecond)
+	transport.minWait = time.Millisecond
+
+	return &http.Client{Transport: transport}
+}
+
+func TestRetryTransport(t *testing.T) {
+	testcases := []struct {
+		name           string
+		method         string
+		failStatus     int
+		failCount      int
+		maxRetries     int
+		wantStatus     int
+		wantReqCounter int32
+	}{
+		{
+			name:           "get is retried on 503",
+			method:         http.MethodGet,
+			failStatus:     http.StatusServiceUnavailable,
+			failCount:      2,
+			maxRetries:     3,
+			wantStatus:     http.StatusOK,
+			wantReqCounter: 3,
+		},
+		{
+			name:           "post is retried on 429",
+			method:         http.MethodPost,
+			failStatus:     http.StatusTooManyRequests,
+			failCount:      2,
+			maxRetries:     3,
+			wantStatus:     http.StatusOK,
+			wantReqCounter: 3,
+		},
+		{
+			name:           "post is not retried on 502",
+			method:         http.MethodPost,
+			failStatus:     http.StatusBadGateway,
+			failCount:      1,
+			maxRetries:     3,
+			wantStatus:     http.StatusBadGateway,
+			wantReqCounter: 1,
+		},
+		{
+			name:           "delete is not retried on 400",
+			method:         http.MethodDelete,
+			failStatus:     http.StatusBadRequest,
+			failCount:      1,
+			maxRetries:     3,
+			wantStatus:     http.StatusBadRequest,
+			wantReqCounter: 1,
+		},
+		{
+			name:           "retries stop after maxRetries",
+			method:         http.MethodGet,
+			failStatus:     http.StatusTooManyRequests,
+			failCount:      5,
+			maxRetries:     2,
+			wantStatus:     http.StatusTooManyRequests,
+			wantReqCounter: 3,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, cnt := newFailingServer(t, tc.failStatus, tc.failCount)
+
+			req, err := http.NewRequest(tc.method, srv.URL, strings.NewReader("body"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := newTestRetryClient(tc.maxRetries).Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %s", err)
+			}
+			resp.Body.Close() //nolint:errcheck
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("got status code %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+
+			if got := atomic.LoadInt32(cnt); got != tc.wantReqCounter {
+				t.Errorf("server received %d requests, want %d", got, tc.wantReqCounter)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_BackoffIsCappedByMaxWait(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, 10, 5*time.Second)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		if wait := transport.backoff(attempt, nil); wait > 5*time.Second {
+			t.Errorf("attempt %d: backoff %s exceeds max wait", attempt, wait)
+		}
+	}
+
+	resp := http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if wait := transport.backoff(0, &resp); wait != 5*time.Second {
+		t.Errorf("expected Retry-After to be capped to 5s, got: %s", wait)
+	}
+}
+
+func TestRetryTransport_DoesNotWaitBeyondContextDeadline(t *testing.T) {
+	var cnt int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&cnt, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clt := http.Client{Transport: newRetryTransport(http.DefaultTransport, 3, time.Minute)}
+
+	start := time.Now()
+	resp, err := clt.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("got status code %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+
+	if got := atomic.LoadInt32(&cnt); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %s, expected it to return without waiting", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("3"); !ok || wait != 3*time.Second {
+		t.Errorf("parsing seconds failed, got: %s, %t", wait, ok)
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait < 59*time.Minute {
+		t.Errorf("parsing http-date failed, got: %s, %t", wait, ok)
+	}
+
+	if _, ok := parseRetryAfter("invalid"); ok {
+		t.Error("parsing invalid value succeeded")
+	}
+}
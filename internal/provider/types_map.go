@@ -48,3 +48,16 @@ func intStrMapGet(m map[int]string, key *int) (string, error) {
 
 	return "", fmt.Errorf("key '%d' not found", key)
 }
+
+// strMapFromInterfaceMap converts the value of a TypeMap attribute with
+// string elements to a map[string]string.
+func strMapFromInterfaceMap(v interface{}) map[string]string {
+	m, _ := v.(map[string]interface{})
+	res := make(map[string]string, len(m))
+
+	for k, v := range m {
+		res[k] = v.(string)
+	}
+
+	return res
+}
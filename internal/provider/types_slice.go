@@ -61,3 +61,26 @@ func strSliceAsNormalizedStr(in []string, sep string) string {
 
 	return strings.Join(normalizedSl, sep)
 }
+
+// strSliceDifferenceFold returns the elements of a that are not in b.
+// Elements are compared case-insensitively.
+func strSliceDifferenceFold(a, b []string) []string {
+	var res []string
+
+	for _, elemA := range a {
+		found := false
+
+		for _, elemB := range b {
+			if strings.EqualFold(elemA, elemB) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			res = append(res, elemA)
+		}
+	}
+
+	return res
+}
@@ -2,8 +2,13 @@ package provider
 
 import (
 	"math"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var validateIsInt32 = validation.ToDiagFunc(validation.IntBetween(math.MinInt32, math.MaxInt32))
+
+var validateIsIDStr = validation.ToDiagFunc(
+	validation.StringMatch(regexp.MustCompile(`^[0-9]+$`), "must be a positive integer"),
+)
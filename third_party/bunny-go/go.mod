@@ -0,0 +1,15 @@
+module github.com/simplesurance/bunny-go
+
+go 1.18
+
+require (
+	github.com/google/go-querystring v1.1.0
+	github.com/google/uuid v1.3.0
+	github.com/stretchr/testify v1.7.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
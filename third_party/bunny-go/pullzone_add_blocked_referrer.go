@@ -0,0 +1,23 @@
+package bunny
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddBlockedReferrerOptions represents the message that is sent to the
+// Add Blocked Referrer API Endpoint.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_addblockedreferrer
+type AddBlockedReferrerOptions struct {
+	// Hostname is the referrer hostname that is blocked. (Required)
+	Hostname *string `json:"BlockedHostname,omitempty"`
+}
+
+// AddBlockedReferrer adds a hostname to the blocked referrers of the Pull Zone.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_addblockedreferrer
+func (s *PullZoneService) AddBlockedReferrer(ctx context.Context, pullZoneID int64, opts *AddBlockedReferrerOptions) error {
+	path := fmt.Sprintf("pullzone/%d/addBlockedReferrer", pullZoneID)
+	return resourcePost(ctx, s.client, path, opts)
+}
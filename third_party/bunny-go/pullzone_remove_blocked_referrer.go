@@ -0,0 +1,24 @@
+package bunny
+
+import (
+	"context"
+	"fmt"
+)
+
+// RemoveBlockedReferrerOptions represents the message that is sent to the
+// Remove Blocked Referrer API Endpoint.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_removeblockedreferrer
+type RemoveBlockedReferrerOptions struct {
+	// Hostname is the referrer hostname that is unblocked. (Required)
+	Hostname *string `json:"BlockedHostname,omitempty"`
+}
+
+// RemoveBlockedReferrer removes a hostname from the blocked referrers of the
+// Pull Zone.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_removeblockedreferrer
+func (s *PullZoneService) RemoveBlockedReferrer(ctx context.Context, pullZoneID int64, opts *RemoveBlockedReferrerOptions) error {
+	path := fmt.Sprintf("pullzone/%d/removeBlockedReferrer", pullZoneID)
+	return resourcePost(ctx, s.client, path, opts)
+}